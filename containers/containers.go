@@ -29,10 +29,17 @@
 //         HashMap
 //         TreeMap
 //     Iterator -- import containers
+//       BidirectionalIterator
+//     IntKey, StringKey -- ready-made set and map keys: import containers
 //
 
 package containers
 
+import (
+	"math/rand"
+	"reflect"
+)
+
 // Container is the root type in the containers hierarchy.
 // Every Container includes these operations.
 type Container interface {
@@ -57,6 +64,15 @@ type Iterator interface {
 	Next() (interface{}, bool) // return the next element and ok indication
 }
 
+// BidirectionalIterator is the interface for iterators that can also move
+// backward. Such an iterator sits between two elements: Next returns the one
+// after it and moves forward, and Prev returns the one before it and moves
+// back, so calling Prev right after Next returns the same element again.
+type BidirectionalIterator interface {
+	Iterator
+	Prev() (interface{}, bool) // return the previous element and ok indication
+}
+
 // Values stored in sets or maps must be Equalers
 type Equaler interface {
 	Equal(x interface{}) bool // true iff x is identical to the receiver
@@ -73,3 +89,106 @@ type Comparer interface {
 	Equaler
 	Less(x interface{}) bool // true iff the receiver is less than x
 }
+
+// Sample returns k elements chosen uniformly at random from c, or all of c's
+// elements if it has no more than k, using a random number generator seeded
+// with seed. This is reservoir sampling: the first k elements fill the
+// reservoir, and the ith element after that replaces a random one of them
+// with probability k/i, so c need only be traversed once.
+func Sample(c Collection, k int, seed int64) []interface{} {
+	if k <= 0 {
+		return []interface{}{}
+	}
+	r := rand.New(rand.NewSource(seed))
+	result := make([]interface{}, 0, k)
+	iter := c.NewIterator()
+	seen := 0
+	for e, ok := iter.Next(); ok; e, ok = iter.Next() {
+		seen++
+		if len(result) < k {
+			result = append(result, e)
+		} else if j := r.Intn(seen); j < k {
+			result[j] = e
+		}
+	}
+	return result
+}
+
+// ToSlice returns a new slice holding the elements of c in iteration order.
+func ToSlice(c Collection) []interface{} {
+	result := make([]interface{}, 0, c.Size())
+	iter := c.NewIterator()
+	for e, ok := iter.Next(); ok; e, ok = iter.Next() {
+		result = append(result, e)
+	}
+	return result
+}
+
+// ElementsEqual compares a and b using == unless that panics, and using
+// reflect.DeepEqual if it does. Checking that the type is comparable is not
+// enough: == on a struct with an interface{} field panics if the field holds
+// a slice, for example.
+func ElementsEqual(a, b interface{}) (result bool) {
+	defer func() {
+		if recover() != nil {
+			result = reflect.DeepEqual(a, b)
+		}
+	}()
+	return a == b
+}
+
+// EqualUnordered returns true iff a and b are the same size and each contains
+// every element of the other, according to their Contains operations, in any
+// order. This is exactly set equality; for collections with duplicate
+// elements, only which elements are present is compared, not how many times.
+func EqualUnordered(a, b Collection) bool {
+	if a.Size() != b.Size() {
+		return false
+	}
+	return All(a, b.Contains) && All(b, a.Contains)
+}
+
+// EqualOrdered returns true iff a and b have the same elements (compared
+// using ElementsEqual) in the same iteration order.
+func EqualOrdered(a, b Collection) bool {
+	if a.Size() != b.Size() {
+		return false
+	}
+	aIter, bIter := a.NewIterator(), b.NewIterator()
+	for e, ok := aIter.Next(); ok; e, ok = aIter.Next() {
+		if f, ok := bIter.Next(); !ok || !ElementsEqual(e, f) {
+			return false
+		}
+	}
+	return bIter.Done()
+}
+
+// Count returns the number of elements of c for which pred is true.
+func Count(c Collection, pred func(interface{}) bool) int {
+	result := 0
+	iter := c.NewIterator()
+	for e, ok := iter.Next(); ok; e, ok = iter.Next() {
+		if pred(e) {
+			result++
+		}
+	}
+	return result
+}
+
+// Any returns true iff pred is true of some element of c. It stops at the
+// first such element, so pred may not be called on every element.
+func Any(c Collection, pred func(interface{}) bool) bool {
+	iter := c.NewIterator()
+	for e, ok := iter.Next(); ok; e, ok = iter.Next() {
+		if pred(e) {
+			return true
+		}
+	}
+	return false
+}
+
+// All returns true iff pred is true of every element of c, so it is true if
+// c is empty. It stops at the first element for which pred is false.
+func All(c Collection, pred func(interface{}) bool) bool {
+	return !Any(c, func(e interface{}) bool { return !pred(e) })
+}
@@ -0,0 +1,195 @@
+// Test the functions in the containers package.
+// author: C. Fox
+// version: 1/2016
+
+package containers
+
+import (
+	"testing"
+)
+
+// intCollection is a minimal Collection of the ints 0..n-1 for testing.
+type intCollection int
+
+func (c intCollection) Size() int   { return int(c) }
+func (c intCollection) Empty() bool { return c == 0 }
+func (c intCollection) Clear()      {}
+func (c intCollection) Contains(e interface{}) bool {
+	i, ok := e.(int)
+	return ok && 0 <= i && i < int(c)
+}
+func (c intCollection) NewIterator() Iterator { return &intIterator{n: int(c)} }
+func (c intCollection) Apply(f func(interface{})) {
+	for i := 0; i < int(c); i++ {
+		f(i)
+	}
+}
+
+type intIterator struct{ next, n int }
+
+func (iter *intIterator) Reset()     { iter.next = 0 }
+func (iter *intIterator) Done() bool { return iter.n <= iter.next }
+func (iter *intIterator) Next() (interface{}, bool) {
+	if iter.Done() {
+		return nil, false
+	}
+	iter.next++
+	return iter.next - 1, true
+}
+
+func TestSample(t *testing.T) {
+	if s := Sample(intCollection(0), 3, 1); len(s) != 0 {
+		t.Errorf("Sample of an empty collection should be empty but is %v", s)
+	}
+	if s := Sample(intCollection(10), 0, 1); len(s) != 0 {
+		t.Errorf("Sample of size 0 should be empty but is %v", s)
+	}
+	if s := Sample(intCollection(5), 8, 1); len(s) != 5 {
+		t.Errorf("Sample bigger than the collection should have all 5 elements but is %v", s)
+	}
+
+	// samples are distinct elements of the collection and depend on the seed
+	c := intCollection(1000)
+	s := Sample(c, 20, 99)
+	if len(s) != 20 {
+		t.Errorf("Sample should have 20 elements but has %v", len(s))
+	}
+	seen := make(map[interface{}]bool)
+	for _, e := range s {
+		if !c.Contains(e) || seen[e] {
+			t.Errorf("Sample %v has an element not in the collection or a repeat", s)
+			break
+		}
+		seen[e] = true
+	}
+	again := Sample(c, 20, 99)
+	other := Sample(c, 20, 100)
+	sameAgain, sameOther := true, true
+	for i := range s {
+		sameAgain = sameAgain && s[i] == again[i]
+		sameOther = sameOther && s[i] == other[i]
+	}
+	if !sameAgain {
+		t.Error("Samples with the same seed should be the same")
+	}
+	if sameOther {
+		t.Error("Samples with different seeds should differ")
+	}
+
+	// every element is about equally likely to be chosen
+	counts := make([]int, 10)
+	for seed := int64(0); seed < 5000; seed++ {
+		for _, e := range Sample(intCollection(10), 3, seed) {
+			counts[e.(int)]++
+		}
+	}
+	for i, n := range counts {
+		if n < 1300 || 1700 < n {
+			t.Errorf("Sample chose %v %v times out of 5000 but should choose it about 1500 times", i, n)
+		}
+	}
+}
+
+func TestCountAnyAll(t *testing.T) {
+	isEven := func(e interface{}) bool { return e.(int)%2 == 0 }
+	isSmall := func(e interface{}) bool { return e.(int) < 100 }
+	for n, expected := range []int{0, 1, 1, 2, 2, 3} {
+		c := intCollection(n)
+		if count := Count(c, isEven); count != expected {
+			t.Errorf("Count of evens in 0..%v should be %v but is %v", n-1, expected, count)
+		}
+		if Any(c, isEven) != (0 < n) {
+			t.Errorf("Any even in 0..%v should be %v", n-1, 0 < n)
+		}
+		if All(c, isEven) != (n < 2) {
+			t.Errorf("All even in 0..%v should be %v", n-1, n < 2)
+		}
+		if !All(c, isSmall) || Any(c, func(e interface{}) bool { return !isSmall(e) }) {
+			t.Errorf("All of 0..%v should be small", n-1)
+		}
+	}
+
+	// Any and All stop as soon as they know the answer
+	calls := 0
+	isThree := func(e interface{}) bool { calls++; return e.(int) == 3 }
+	if !Any(intCollection(10), isThree) || calls != 4 {
+		t.Errorf("Any should stop after 4 calls but makes %v", calls)
+	}
+	calls = 0
+	if All(intCollection(10), isThree) || calls != 1 {
+		t.Errorf("All should stop after 1 call but makes %v", calls)
+	}
+}
+
+func TestToSlice(t *testing.T) {
+	for n := 0; n < 5; n++ {
+		s := ToSlice(intCollection(n))
+		if len(s) != n || cap(s) != n {
+			t.Errorf("ToSlice of 0..%v should have length and capacity %v but has %v and %v", n-1, n, len(s), cap(s))
+		}
+		for i, e := range s {
+			if e != i {
+				t.Errorf("ToSlice of 0..%v should have %v at %v but has %v", n-1, i, i, e)
+			}
+		}
+	}
+}
+
+func TestEqualOrderedAndUnordered(t *testing.T) {
+	for n := 0; n < 4; n++ {
+		if !EqualOrdered(intCollection(n), intCollection(n)) || !EqualUnordered(intCollection(n), intCollection(n)) {
+			t.Errorf("0..%v should equal itself", n-1)
+		}
+		if EqualOrdered(intCollection(n), intCollection(n+1)) || EqualUnordered(intCollection(n+1), intCollection(n)) {
+			t.Errorf("0..%v should not equal 0..%v", n-1, n)
+		}
+	}
+}
+
+func TestElementsEqual(t *testing.T) {
+	type pair struct{ first, second interface{} }
+	data := []struct {
+		a, b     interface{}
+		expected bool
+	}{
+		{nil, nil, true}, {1, 1, true}, {1, 2, false}, {1, "1", false}, {nil, 1, false},
+		{[]int{1, 2}, []int{1, 2}, true}, {[]int{1, 2}, []int{2, 1}, false}, {[]int{1}, 1, false},
+		{pair{1, []int{1}}, pair{1, []int{1}}, true}, {pair{1, []int{1}}, pair{1, []int{2}}, false},
+	}
+	for _, d := range data {
+		if ElementsEqual(d.a, d.b) != d.expected {
+			t.Errorf("ElementsEqual(%v, %v) should be %v", d.a, d.b, d.expected)
+		}
+	}
+}
+
+func TestKeys(t *testing.T) {
+	if !IntKey(3).Equal(IntKey(3)) || IntKey(3).Equal(IntKey(4)) || IntKey(3).Equal(3) {
+		t.Error("IntKey Equal should be true only for an equal IntKey")
+	}
+	if !IntKey(-2).Less(IntKey(1)) || IntKey(1).Less(IntKey(1)) {
+		t.Error("IntKey Less should order keys numerically")
+	}
+	if !StringKey("ab").Equal(StringKey("ab")) || StringKey("ab").Equal(StringKey("ba")) ||
+		StringKey("ab").Equal("ab") {
+		t.Error("StringKey Equal should be true only for an equal StringKey")
+	}
+	if !StringKey("apple").Less(StringKey("banana")) || StringKey("b").Less(StringKey("a")) {
+		t.Error("StringKey Less should order keys lexicographically")
+	}
+	for _, size := range []int{1, 7, 991} {
+		for _, k := range []int{0, 5, -5, 1000003, -1000003} {
+			if h := IntKey(k).Hash(size); h < 0 || size <= h {
+				t.Errorf("Hash(%v) of IntKey %v should be in 0..%v but is %v", size, k, size-1, h)
+			}
+		}
+		for _, s := range []string{"", "a", "apple", "a much longer string key"} {
+			if h := StringKey(s).Hash(size); h < 0 || size <= h {
+				t.Errorf("Hash(%v) of StringKey %q should be in 0..%v but is %v", size, s, size-1, h)
+			}
+		}
+	}
+	if StringKey("ab").Hash(991) == StringKey("ba").Hash(991) {
+		t.Error("StringKey Hash should depend on the order of the characters")
+	}
+}
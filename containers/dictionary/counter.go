@@ -0,0 +1,100 @@
+// counter.go: Implementation of frequency counters over hash maps
+//
+// author: C. Fox
+// version: 6/2017
+//
+// A Counter counts occurrences of keys, which must be Hashers.
+package dictionary
+
+// Counter ////////////////////////////////////////////////////////////////
+// A HashMap maps each key added to the counter to the number of times it
+// has been added; keys never added are not in the map. A running total of
+// all the counts is kept as well.
+// Invariant: total is the sum of the values in counts
+
+// Counter is a map from keys to the number of times each has been added.
+// The zero value is an empty Counter ready to use.
+type Counter struct {
+	counts HashMap // maps keys to their (positive) int counts
+	total  int     // sum of all the counts
+}
+
+// Add increments the count for key k.
+// Precondition: k is a Hasher.
+// Precondition violation: panic.
+func (c *Counter) Add(k interface{}) {
+	c.counts.Insert(k, c.Count(k)+1)
+	c.total++
+}
+
+// Count returns the number of times k has been added, which is 0 if
+// it has never been added.
+func (c *Counter) Count(k interface{}) int {
+	if n, ok := c.counts.Get(k); ok {
+		return n.(int)
+	}
+	return 0
+}
+
+// Total returns the sum of the counts of all keys.
+func (c *Counter) Total() int { return c.total }
+
+// Size returns the number of distinct keys counted.
+func (c *Counter) Size() int { return c.counts.Size() }
+
+// Clear sets every count back to 0.
+func (c *Counter) Clear() {
+	c.counts.Clear()
+	c.total = 0
+}
+
+// MostCommon returns entries (with int Values) for the n keys with the
+// highest counts, in descending order of count, or for all keys if n >= Size().
+// Keys with equal counts appear in no particular order. As in slice.TopK, a
+// min-heap holds the n highest-count entries seen so far, so the whole job
+// takes O(m lg n) time for m keys.
+func (c *Counter) MostCommon(n int) []Entry {
+	if n <= 0 {
+		return []Entry{}
+	}
+	if c.Size() < n {
+		n = c.Size()
+	}
+	heap := make([]Entry, 0, n)
+	iter := c.counts.NewEntryIterator()
+	for e, ok := iter.Next(); ok; e, ok = iter.Next() {
+		entry := e.(Entry)
+		if len(heap) < n {
+			heap = append(heap, entry)
+			if len(heap) == n {
+				for i := (n - 2) / 2; 0 <= i; i-- {
+					entrySiftDown(heap, i, n-1)
+				}
+			}
+		} else if heap[0].Value.(int) < entry.Value.(int) {
+			heap[0] = entry
+			entrySiftDown(heap, 0, n-1)
+		}
+	}
+	for maxIndex := n - 1; 0 < maxIndex; maxIndex-- {
+		heap[0], heap[maxIndex] = heap[maxIndex], heap[0]
+		entrySiftDown(heap, 0, maxIndex-1)
+	}
+	return heap
+}
+
+// entrySiftDown restores the min-heap property (by int Value) of
+// heap[i..maxIndex] when only heap[i] may be out of place.
+func entrySiftDown(heap []Entry, i, maxIndex int) {
+	tmp := heap[i]
+	for j := 2*i + 1; j <= maxIndex; j = 2*i + 1 {
+		if j < maxIndex && heap[j+1].Value.(int) < heap[j].Value.(int) {
+			j++
+		}
+		if tmp.Value.(int) <= heap[j].Value.(int) {
+			break
+		}
+		heap[i], i = heap[j], j
+	}
+	heap[i] = tmp
+}
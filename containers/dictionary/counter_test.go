@@ -0,0 +1,71 @@
+// Test the Counter data structure.
+//
+// author: C. Fox
+// version: 6/2017
+
+package dictionary
+
+import (
+	"strings"
+	"testing"
+)
+
+// Define a Hasher string type
+type Word string
+
+func (w Word) Equal(c interface{}) bool { return w == c.(Word) }
+func (w Word) Hash(tableSize int) int {
+	result := 0
+	for i := 0; i < len(w); i++ {
+		result = (31*result + int(w[i])) % tableSize
+	}
+	return result
+}
+
+func TestCounter(t *testing.T) {
+	var c Counter
+	if c.Total() != 0 || c.Size() != 0 || c.Count(Word("the")) != 0 || len(c.MostCommon(3)) != 0 {
+		t.Error("Counter should be empty when new")
+	}
+	text := "the cat and the dog and the bird saw the cat"
+	for _, w := range strings.Fields(text) {
+		c.Add(Word(w))
+	}
+	expected := map[Word]int{"the": 4, "cat": 2, "and": 2, "dog": 1, "bird": 1, "saw": 1}
+	for w, n := range expected {
+		if c.Count(w) != n {
+			t.Errorf("Counter should count %v %v times but counts %v", w, n, c.Count(w))
+		}
+	}
+	if c.Count(Word("fish")) != 0 {
+		t.Errorf("Counter should count fish 0 times but counts %v", c.Count(Word("fish")))
+	}
+	if c.Total() != 11 || c.Size() != len(expected) {
+		t.Errorf("Counter should have total 11 and size %v but has %v and %v", len(expected), c.Total(), c.Size())
+	}
+
+	top := c.MostCommon(2)
+	if len(top) != 2 || top[0].Key != Word("the") || top[0].Value != 4 || top[1].Value != 2 ||
+		(top[1].Key != Word("cat") && top[1].Key != Word("and")) {
+		t.Errorf("Counter MostCommon(2) is wrong: %v", top)
+	}
+	all := c.MostCommon(10)
+	if len(all) != len(expected) {
+		t.Errorf("Counter MostCommon(10) should have %v entries but has %v", len(expected), len(all))
+	}
+	for i, e := range all {
+		if expected[e.Key.(Word)] != e.Value {
+			t.Errorf("Counter MostCommon entry %v has the wrong count", e)
+		}
+		if 0 < i && all[i-1].Value.(int) < e.Value.(int) {
+			t.Errorf("Counter MostCommon is not in descending order: %v", all)
+		}
+	}
+	if len(c.MostCommon(0)) != 0 {
+		t.Error("Counter MostCommon(0) should be empty")
+	}
+	c.Clear()
+	if c.Total() != 0 || c.Size() != 0 || c.Count(Word("the")) != 0 {
+		t.Error("Counter should be empty after Clear")
+	}
+}
@@ -8,6 +8,8 @@ package dictionary
 import (
 	"fmt"
 	"testing"
+
+	"containers"
 )
 
 var _ = fmt.Printf // in case we need fmt for debugging
@@ -204,3 +206,267 @@ func testMap(t *testing.T, m Map, name string) {
 		t.Error(name + "fails equality test on equal-size maps with same keys but different values")
 	}
 }
+
+func TestHashMapEntryIterator(t *testing.T) {
+	m := new(HashMap)
+	for iter := m.NewEntryIterator(); !iter.Done(); iter.Next() {
+		t.Error("Empty HashMap should not do entry iteration")
+	}
+	values := map[Integer]string{2: "two", 3: "three", 5: "five", 10: "ten", 20: "twenty"}
+	for k, v := range values {
+		m.Insert(k, v)
+	}
+	count := 0
+	iter := m.NewEntryIterator()
+	for e, ok := iter.Next(); ok; e, ok = iter.Next() {
+		entry := e.(Entry)
+		if values[entry.Key.(Integer)] != entry.Value {
+			t.Errorf("HashMap entry %v should have value %v", entry, values[entry.Key.(Integer)])
+		}
+		count++
+	}
+	if count != m.Size() {
+		t.Errorf("HashMap entry iterator returned %v entries but size is %v", count, m.Size())
+	}
+	count = 0
+	iter.Reset()
+	for !iter.Done() {
+		iter.Next()
+		count++
+	}
+	if count != m.Size() {
+		t.Errorf("Reset HashMap entry iterator returned %v entries but size is %v", count, m.Size())
+	}
+}
+
+func TestKeyDiff(t *testing.T) {
+	maps := []func() Map{func() Map { return new(TreeMap) }, func() Map { return new(HashMap) }}
+	data := []struct {
+		mKeys, nKeys []int
+		onlyM, onlyN int
+	}{{[]int{}, []int{}, 0, 0},
+		{[]int{1, 2, 3}, []int{}, 3, 0},
+		{[]int{1, 2, 3}, []int{1, 2, 3}, 0, 0},
+		{[]int{1, 2, 3}, []int{4, 5}, 3, 2},
+		{[]int{1, 2, 3, 4}, []int{3, 4, 5}, 2, 1},
+		{[]int{2}, []int{1, 2, 3, 4}, 0, 3}}
+	for _, newM := range maps {
+		for _, newN := range maps {
+			for _, d := range data {
+				m, n := newM(), newN()
+				for _, k := range d.mKeys {
+					m.Insert(Integer(k), k)
+				}
+				for _, k := range d.nKeys {
+					n.Insert(Integer(k), -k) // values do not matter
+				}
+				if onlyM, onlyN := m.KeyDiff(n); onlyM != d.onlyM || onlyN != d.onlyN {
+					t.Errorf("%T KeyDiff of %v and %v should be %v, %v but is %v, %v",
+						m, d.mKeys, d.nKeys, d.onlyM, d.onlyN, onlyM, onlyN)
+				}
+			}
+		}
+	}
+}
+
+func TestNewMapFromPairs(t *testing.T) {
+	keys := []interface{}{Integer(3), Integer(1), Integer(4), Integer(1), Integer(5)}
+	values := []interface{}{"three", "one", "four", "uno", "five"}
+	tm, err := NewTreeMapFromPairs(keys, values)
+	if err != nil {
+		t.Fatalf("NewTreeMapFromPairs failed: %v", err)
+	}
+	hm, err := NewHashMapFromPairs(keys, values)
+	if err != nil {
+		t.Fatalf("NewHashMapFromPairs failed: %v", err)
+	}
+	expected := map[Integer]string{3: "three", 1: "uno", 4: "four", 5: "five"}
+	for _, m := range []Map{tm, hm} {
+		if m.Size() != len(expected) {
+			t.Errorf("%T from pairs should have %v pairs but has %v", m, len(expected), m.Size())
+		}
+		for k, v := range expected {
+			if w, ok := m.Get(k); !ok || w != v {
+				t.Errorf("%T from pairs should map %v to %v but maps it to %v", m, k, v, w)
+			}
+		}
+	}
+	if !tm.IsEqual(hm) {
+		t.Error("Maps from the same pairs should be equal")
+	}
+
+	if m, err := NewTreeMapFromPairs(nil, nil); err != nil || !m.Empty() {
+		t.Error("NewTreeMapFromPairs with no pairs should make an empty map")
+	}
+	if m, err := NewHashMapFromPairs(nil, nil); err != nil || !m.Empty() {
+		t.Error("NewHashMapFromPairs with no pairs should make an empty map")
+	}
+	if _, err := NewTreeMapFromPairs(keys, values[:4]); err == nil {
+		t.Error("NewTreeMapFromPairs should fail when the slice lengths differ")
+	}
+	if _, err := NewHashMapFromPairs(keys[:2], values); err == nil {
+		t.Error("NewHashMapFromPairs should fail when the slice lengths differ")
+	}
+}
+
+func TestInvert(t *testing.T) {
+	for _, m := range []Map{new(TreeMap), new(HashMap)} {
+		// an injective map inverts cleanly
+		for k := 1; k <= 5; k++ {
+			m.Insert(Integer(k), Integer(10*k))
+		}
+		inv := m.Invert()
+		if inv.Size() != 5 {
+			t.Errorf("%T Invert should have 5 pairs but has %v", m, inv.Size())
+		}
+		for k := 1; k <= 5; k++ {
+			if v, ok := inv.Get(Integer(10 * k)); !ok || v != Integer(k) {
+				t.Errorf("%T Invert should map %v to %v but maps it to %v", m, 10*k, k, v)
+			}
+		}
+		if !inv.Invert().IsEqual(m) {
+			t.Errorf("%T Invert of Invert should equal the original map", m)
+		}
+		if keys := m.FindKeysByValue(Integer(30)); len(keys) != 1 || keys[0] != Integer(3) {
+			t.Errorf("%T FindKeysByValue(30) should be [3] but is %v", m, keys)
+		}
+		if keys := m.FindKeysByValue(Integer(7)); len(keys) != 0 {
+			t.Errorf("%T FindKeysByValue(7) should be empty but is %v", m, keys)
+		}
+
+		// in a non-injective map one of the colliding keys wins
+		m.Clear()
+		for k := 1; k <= 6; k++ {
+			m.Insert(Integer(k), Integer(k%2))
+		}
+		inv = m.Invert()
+		if inv.Size() != 2 {
+			t.Errorf("%T non-injective Invert should have 2 pairs but has %v", m, inv.Size())
+		}
+		for _, v := range []Integer{0, 1} {
+			w, ok := inv.Get(v)
+			if !ok || int(w.(Integer))%2 != int(v) {
+				t.Errorf("%T non-injective Invert maps %v to %v", m, v, w)
+			}
+			keys := m.FindKeysByValue(v)
+			if len(keys) != 3 {
+				t.Errorf("%T FindKeysByValue(%v) should find 3 keys but finds %v", m, v, keys)
+			}
+			for _, k := range keys {
+				if int(k.(Integer))%2 != int(v) {
+					t.Errorf("%T FindKeysByValue(%v) finds wrong key %v", m, v, k)
+				}
+			}
+		}
+		if inv := new(HashMap).Invert(); !inv.Empty() {
+			t.Error("Invert of an empty map should be empty")
+		}
+	}
+}
+
+func TestTreeMapWithValueIndex(t *testing.T) {
+	m := NewTreeMapWithValueIndex()
+	if m.Contains(Integer(1)) || m.Contains("abc") {
+		t.Error("Indexed TreeMap should not contain anything when new")
+	}
+	for k := 1; k <= 4; k++ {
+		m.Insert(Integer(k), Integer(10*k))
+	}
+	for k := 1; k <= 4; k++ {
+		if !m.Contains(Integer(10 * k)) {
+			t.Errorf("Indexed TreeMap should contain %v", 10*k)
+		}
+	}
+	if m.Contains(Integer(1)) || m.Contains("abc") {
+		t.Error("Indexed TreeMap contains a value it does not hold")
+	}
+
+	// overwriting a key removes its old value unless another key has it
+	m.Insert(Integer(1), Integer(20)) // 10 is gone, 20 is held by 1 and 2
+	if m.Contains(Integer(10)) || !m.Contains(Integer(20)) {
+		t.Error("Indexed TreeMap Contains is wrong after an overwrite")
+	}
+	m.Insert(Integer(1), Integer(20)) // same pair again
+	m.Delete(Integer(2))
+	if !m.Contains(Integer(20)) {
+		t.Error("Indexed TreeMap should still contain 20 held by key 1")
+	}
+	m.Delete(Integer(1))
+	if m.Contains(Integer(20)) {
+		t.Error("Indexed TreeMap should not contain 20 after its keys are deleted")
+	}
+	m.Delete(Integer(9)) // not there
+	if m.Size() != 2 || !m.Contains(Integer(30)) || !m.Contains(Integer(40)) {
+		t.Errorf("Indexed TreeMap should hold 30 and 40 but is %v", m)
+	}
+
+	// the index agrees with a full scan
+	plain := new(TreeMap)
+	iter := m.NewKeyIterator()
+	for k, ok := iter.Next(); ok; k, ok = iter.Next() {
+		v, _ := m.Get(k)
+		plain.Insert(k, v)
+	}
+	for v := 0; v <= 50; v += 10 {
+		if m.Contains(Integer(v)) != plain.Contains(Integer(v)) {
+			t.Errorf("Indexed TreeMap Contains(%v) disagrees with a full scan", v)
+		}
+	}
+	m.Clear()
+	if m.Contains(Integer(30)) {
+		t.Error("Indexed TreeMap should not contain anything after Clear")
+	}
+}
+
+func TestIsSubmap(t *testing.T) {
+	maps := []func() Map{func() Map { return new(TreeMap) }, func() Map { return new(HashMap) }}
+	fill := func(m Map, keys ...int) Map {
+		for _, k := range keys {
+			m.Insert(Integer(k), k*k)
+		}
+		return m
+	}
+	for _, newM := range maps {
+		for _, newN := range maps {
+			empty, small, big := newM(), fill(newM(), 1, 2), fill(newN(), 1, 2, 3)
+			if !empty.IsSubmap(big) || !small.IsSubmap(big) || big.IsSubmap(small) {
+				t.Errorf("%T IsSubmap of %T is wrong for a strict submap", small, big)
+			}
+			same := fill(newN(), 2, 1)
+			if !small.IsSubmap(same) || !same.IsSubmap(small) {
+				t.Errorf("%T IsSubmap of %T should hold both ways for equal maps", small, same)
+			}
+			big.Insert(Integer(2), -1)
+			if small.IsSubmap(big) {
+				t.Errorf("%T IsSubmap of %T should fail when a value differs", small, big)
+			}
+
+			if keys := small.SharedKeys(big); len(keys) != 2 || !big.HasKey(keys[0]) || !big.HasKey(keys[1]) {
+				t.Errorf("%T SharedKeys with %T should be two keys but is %v", small, big, keys)
+			}
+			if keys := fill(newM(), 4, 5).SharedKeys(big); len(keys) != 0 {
+				t.Errorf("%T SharedKeys of disjoint maps should be empty but is %v", small, keys)
+			}
+			if keys := fill(newM(), 3, 4).SharedKeys(big); len(keys) != 1 || keys[0] != Integer(3) {
+				t.Errorf("%T SharedKeys should be [3] but is %v", small, keys)
+			}
+		}
+	}
+}
+
+func TestCount(t *testing.T) {
+	m := new(HashMap)
+	for k := 1; k <= 6; k++ {
+		m.Insert(Integer(k), k*k)
+	}
+	isOdd := func(v interface{}) bool { return v.(int)%2 == 1 }
+	if count := containers.Count(m, isOdd); count != 3 {
+		t.Errorf("Count of odd values in a HashMap should be 3 but is %v", count)
+	}
+	if !containers.Any(m, isOdd) || containers.All(m, isOdd) {
+		t.Error("HashMap should have some but not all odd values")
+	}
+	if !containers.All(m, func(v interface{}) bool { return 0 < v.(int) }) {
+		t.Error("HashMap values should all be positive")
+	}
+}
@@ -11,6 +11,8 @@
 package dictionary
 
 import (
+	"fmt"
+
 	"containers"
 	"containers/internal/hashtbl"
 	"containers/internal/tree"
@@ -18,13 +20,18 @@ import (
 
 // Map is the interface for maps in the container hierarchy.
 type Map interface {
-	containers.Collection                  // Size, Clear, Empty, Contains, NewIterator, Apply
-	Insert(k, v interface{})               // put pair <k,v> in the map; replace <k,w> if any
-	Delete(k interface{})                  // remove pair <k,v> from the map, if any
-	Get(k interface{}) (interface{}, bool) // retrieve a value by its key
-	HasKey(k interface{}) bool             // true iff <k,v> is in the map
-	IsEqual(n Map) bool                    // true iff reciever and m have the same pairs
-	NewKeyIterator() containers.Iterator   // iterate over keys
+	containers.Collection                        // Size, Clear, Empty, Contains, NewIterator, Apply
+	Insert(k, v interface{})                     // put pair <k,v> in the map; replace <k,w> if any
+	Delete(k interface{})                        // remove pair <k,v> from the map, if any
+	Get(k interface{}) (interface{}, bool)       // retrieve a value by its key
+	HasKey(k interface{}) bool                   // true iff <k,v> is in the map
+	IsEqual(n Map) bool                          // true iff reciever and m have the same pairs
+	NewKeyIterator() containers.Iterator         // iterate over keys
+	KeyDiff(n Map) (int, int)                    // count keys only in the receiver and only in n
+	Invert() Map                                 // make a map from each value to its key
+	FindKeysByValue(v interface{}) []interface{} // return every key whose value is v
+	IsSubmap(n Map) bool                         // true iff every pair in the receiver is in n
+	SharedKeys(n Map) []interface{}              // return the keys in both the receiver and n
 }
 
 // Comparable pairs ///////////////////////////////////////////////////////
@@ -46,21 +53,60 @@ func (kv *cKeyValue) Less(kw interface{}) bool {
 // TreeMap is the data structure for a search-tree-based implementation
 // of maps that uses pointers to cKeyValue instances in the nodes.
 type TreeMap struct {
-	tree tree.AVLTree // holds cKeyValue instances as node values
+	tree       tree.AVLTree // holds cKeyValue instances as node values
+	valueIndex *HashMap     // how many keys map to each value; nil if not indexed
+}
+
+// NewTreeMapWithValueIndex makes an empty tree map that also keeps an index
+// of its values, so that Contains takes O(1) time rather than O(n). The index
+// is a hash map from each value to the number of keys mapped to it, so it
+// roughly doubles the memory used by the map, and Insert and Delete do a bit
+// more work to maintain it. Contains compares values using Equal rather than ==.
+// Precondition: the values inserted are Hashers.
+// Precondition violation: panic on Insert.
+func NewTreeMapWithValueIndex() *TreeMap {
+	return &TreeMap{valueIndex: new(HashMap)}
+}
+
+// NewTreeMapFromPairs makes a tree map holding the pairs <keys[i],values[i]>;
+// a later pair replaces an earlier one with the same key.
+// Precondition: len(keys) == len(values) and the keys are Comparers.
+// Precondition violation: return nil and an error if the lengths differ;
+// panic if a key is not a Comparer.
+// Normal return: the new map and nil.
+func NewTreeMapFromPairs(keys, values []interface{}) (*TreeMap, error) {
+	if len(keys) != len(values) {
+		return nil, fmt.Errorf("NewTreeMapFromPairs: %d keys but %d values", len(keys), len(values))
+	}
+	result := new(TreeMap)
+	for i, k := range keys {
+		result.Insert(k, values[i])
+	}
+	return result, nil
 }
 
 // Size indicates how many items in the tree map.
 func (m *TreeMap) Size() int { return m.tree.Size() }
 
 // Clear removes all items from a tree map.
-func (m *TreeMap) Clear() { m.tree.Clear() }
+func (m *TreeMap) Clear() {
+	m.tree.Clear()
+	if m.valueIndex != nil {
+		m.valueIndex.Clear()
+	}
+}
 
 // Empty returns true just in case the tree map has no contents.
 func (m *TreeMap) Empty() bool { return m.tree.Empty() }
 
 // Contains returns true just in case its argument v is a value
-// held in a key-value pair in the tree map.
+// held in a key-value pair in the tree map. This takes O(n) time
+// unless the map was made by NewTreeMapWithValueIndex.
 func (m *TreeMap) Contains(v interface{}) bool {
+	if m.valueIndex != nil {
+		_, ok := v.(containers.Hasher)
+		return ok && m.valueIndex.HasKey(v)
+	}
 	iterator := m.NewIterator()
 	for value, ok := iterator.Next(); ok; value, ok = iterator.Next() {
 		if value == v {
@@ -80,15 +126,39 @@ func (m *TreeMap) Apply(f func(interface{})) {
 // Insert puts the key-value pair <k,v> into a map.
 // It replaces the pair <k,w> if it is already there.
 func (m *TreeMap) Insert(k, v interface{}) {
+	if m.valueIndex != nil {
+		m.unindexValue(k)
+		count, _ := m.valueIndex.Get(v)
+		n, _ := count.(int)
+		m.valueIndex.Insert(v, n+1)
+	}
 	m.tree.Add(&cKeyValue{k.(containers.Comparer), v})
 }
 
 // Delete removes a pair <k,v> from a map given the key k.
 // Do nothing if it is not there.
 func (m *TreeMap) Delete(k interface{}) {
+	if m.valueIndex != nil {
+		m.unindexValue(k)
+	}
 	m.tree.Remove(&cKeyValue{key: k.(containers.Comparer)})
 }
 
+// unindexValue removes one count of the value mapped to k, if any, from the
+// value index, dropping the value altogether when no other key maps to it.
+func (m *TreeMap) unindexValue(k interface{}) {
+	v, ok := m.Get(k)
+	if !ok {
+		return
+	}
+	count, _ := m.valueIndex.Get(v)
+	if count.(int) == 1 {
+		m.valueIndex.Delete(v)
+	} else {
+		m.valueIndex.Insert(v, count.(int)-1)
+	}
+}
+
 // Get retrieves a key-value pair by its key.
 // Precondition: The key is in the map.
 // Precondition violation: return nil, false.
@@ -129,6 +199,39 @@ func (m *TreeMap) IsEqual(n Map) bool {
 	return true
 }
 
+// KeyDiff returns the number of keys in the receiver map but not in n,
+// and the number of keys in n but not in the receiver.
+func (m *TreeMap) KeyDiff(n Map) (onlyInReceiver, onlyInOther int) {
+	return keyDiff(m, n)
+}
+
+// Invert returns a new TreeMap mapping each value in the receiver to its key.
+// If several keys have the same value, one of them (which one is not
+// specified) becomes that value's key in the result.
+// Precondition: the values are Comparers.
+// Precondition violation: panic.
+func (m *TreeMap) Invert() Map {
+	return invertInto(new(TreeMap), m)
+}
+
+// FindKeysByValue returns every key whose value is v (compared using ==),
+// in key iteration order. It must look at every pair in the map.
+func (m *TreeMap) FindKeysByValue(v interface{}) []interface{} {
+	return findKeysByValue(m, v)
+}
+
+// IsSubmap returns true just in case every pair <k,v> in the receiver
+// is also in n.
+func (m *TreeMap) IsSubmap(n Map) bool {
+	return isSubmap(m, n)
+}
+
+// SharedKeys returns the keys present in both the receiver and n, in the
+// receiver's key iteration order.
+func (m *TreeMap) SharedKeys(n Map) []interface{} {
+	return sharedKeys(m, n)
+}
+
 // TreeMap Value Iterator ////////////////////////////////////////////////
 // treeMapValueIterator keeps track of the state of value iteration over a
 // search tree whose nodes are pointers to instances of key-value pairs.
@@ -201,6 +304,23 @@ type HashMap struct {
 	table hashtbl.HashTable // holds hKeyValue instances as node values
 }
 
+// NewHashMapFromPairs makes a hash map holding the pairs <keys[i],values[i]>;
+// a later pair replaces an earlier one with the same key.
+// Precondition: len(keys) == len(values) and the keys are Hashers.
+// Precondition violation: return nil and an error if the lengths differ;
+// panic if a key is not a Hasher.
+// Normal return: the new map and nil.
+func NewHashMapFromPairs(keys, values []interface{}) (*HashMap, error) {
+	if len(keys) != len(values) {
+		return nil, fmt.Errorf("NewHashMapFromPairs: %d keys but %d values", len(keys), len(values))
+	}
+	result := new(HashMap)
+	for i, k := range keys {
+		result.Insert(k, values[i])
+	}
+	return result, nil
+}
+
 // Size returns the number of values in the map.
 func (m *HashMap) Size() int { return m.table.Size() }
 
@@ -281,6 +401,39 @@ func (m *HashMap) IsEqual(n Map) bool {
 	return true
 }
 
+// KeyDiff returns the number of keys in the receiver map but not in n,
+// and the number of keys in n but not in the receiver.
+func (m *HashMap) KeyDiff(n Map) (onlyInReceiver, onlyInOther int) {
+	return keyDiff(m, n)
+}
+
+// Invert returns a new HashMap mapping each value in the receiver to its key.
+// If several keys have the same value, one of them (which one is not
+// specified) becomes that value's key in the result.
+// Precondition: the values are Hashers.
+// Precondition violation: panic.
+func (m *HashMap) Invert() Map {
+	return invertInto(new(HashMap), m)
+}
+
+// FindKeysByValue returns every key whose value is v (compared using ==),
+// in key iteration order. It must look at every pair in the map.
+func (m *HashMap) FindKeysByValue(v interface{}) []interface{} {
+	return findKeysByValue(m, v)
+}
+
+// IsSubmap returns true just in case every pair <k,v> in the receiver
+// is also in n.
+func (m *HashMap) IsSubmap(n Map) bool {
+	return isSubmap(m, n)
+}
+
+// SharedKeys returns the keys present in both the receiver and n, in the
+// receiver's key iteration order.
+func (m *HashMap) SharedKeys(n Map) []interface{} {
+	return sharedKeys(m, n)
+}
+
 // NewIterator creates and returns a new external iterator that
 // traverses values (not keys) in the map.
 func (m *HashMap) NewIterator() containers.Iterator {
@@ -292,3 +445,110 @@ func (m *HashMap) NewIterator() containers.Iterator {
 func (m *HashMap) NewKeyIterator() containers.Iterator {
 	return m.table.NewKeyIterator()
 }
+
+// NewEntryIterator creates and returns a new external iterator that
+// traverses key-value pairs in the map; each value it returns is an Entry.
+func (m *HashMap) NewEntryIterator() containers.Iterator {
+	result := new(hashMapEntryIterator)
+	result.tableIter = m.table.NewEntryIterator()
+	return result
+}
+
+// Entry is a key-value pair returned by entry iterators.
+type Entry struct {
+	Key   interface{} // key of the pair
+	Value interface{} // value mapped to the key
+}
+
+// HashMap Entry Iterator ////////////////////////////////////////////////
+// hashMapEntryIterator keeps track of the state of entry iteration over
+// a hash table, converting its entries to map entries.
+type hashMapEntryIterator struct {
+	tableIter containers.Iterator // iterator over the hash table entries
+}
+
+// Reset prepares for a new iteration.
+func (iter *hashMapEntryIterator) Reset() { iter.tableIter.Reset() }
+
+// Done returns true iff iteration is complete.
+func (iter *hashMapEntryIterator) Done() bool { return iter.tableIter.Done() }
+
+// Next returns the next key-value pair in the iteration.
+// Precondition: Iteration is not complete.
+// Precondition violation: return nil and false.
+// Normal return: return the pair as an Entry and true.
+func (iter *hashMapEntryIterator) Next() (interface{}, bool) {
+	e, ok := iter.tableIter.Next()
+	if !ok {
+		return nil, false
+	}
+	entry := e.(hashtbl.Entry)
+	return Entry{entry.Key, entry.Value}, true
+}
+
+// Helper functions ///////////////////////////////////////////////////////
+
+// keyDiff counts the keys of m missing from n and the keys of n missing
+// from m by iterating over the keys of each map.
+func keyDiff(m, n Map) (onlyInM, onlyInN int) {
+	iter := m.NewKeyIterator()
+	for k, ok := iter.Next(); ok; k, ok = iter.Next() {
+		if !n.HasKey(k) {
+			onlyInM++
+		}
+	}
+	iter = n.NewKeyIterator()
+	for k, ok := iter.Next(); ok; k, ok = iter.Next() {
+		if !m.HasKey(k) {
+			onlyInN++
+		}
+	}
+	return onlyInM, onlyInN
+}
+
+// invertInto inserts a pair <v,k> into result for every pair <k,v> in m,
+// and returns result.
+func invertInto(result, m Map) Map {
+	iter := m.NewKeyIterator()
+	for k, ok := iter.Next(); ok; k, ok = iter.Next() {
+		v, _ := m.Get(k)
+		result.Insert(v, k)
+	}
+	return result
+}
+
+// findKeysByValue returns the keys in m whose value is v.
+func findKeysByValue(m Map, v interface{}) []interface{} {
+	result := []interface{}{}
+	iter := m.NewKeyIterator()
+	for k, ok := iter.Next(); ok; k, ok = iter.Next() {
+		if w, _ := m.Get(k); w == v {
+			result = append(result, k)
+		}
+	}
+	return result
+}
+
+// isSubmap returns true iff every pair in m is in n.
+func isSubmap(m, n Map) bool {
+	iter := m.NewKeyIterator()
+	for k, ok := iter.Next(); ok; k, ok = iter.Next() {
+		mValue, _ := m.Get(k)
+		if nValue, ok := n.Get(k); !ok || nValue != mValue {
+			return false
+		}
+	}
+	return true
+}
+
+// sharedKeys returns the keys of m that are also keys of n.
+func sharedKeys(m, n Map) []interface{} {
+	result := []interface{}{}
+	iter := m.NewKeyIterator()
+	for k, ok := iter.Next(); ok; k, ok = iter.Next() {
+		if n.HasKey(k) {
+			result = append(result, k)
+		}
+	}
+	return result
+}
@@ -0,0 +1,157 @@
+// stringMap.go: Implementation of hash maps with plain string keys
+//
+// author: C. Fox
+// version: 6/2017
+//
+// A StringMap is a HashMap whose keys are ordinary strings, so that callers
+// need not define a Hasher type of their own.
+package dictionary
+
+import "containers"
+
+// StringMap //////////////////////////////////////////////////////////////
+// A HashMap holds the pairs, with each string key wrapped as a
+// containers.StringKey, which hashes with FNV-1a.
+// Keys are unwrapped again before they are handed back to callers.
+
+// StringMap is a hash map whose keys are strings.
+// The zero value is an empty StringMap ready to use.
+type StringMap struct {
+	pairs HashMap // maps StringKey keys to values
+}
+
+// Size returns the number of values in the map.
+func (m *StringMap) Size() int { return m.pairs.Size() }
+
+// Clear makes the map empty.
+func (m *StringMap) Clear() { m.pairs.Clear() }
+
+// Empty returns true iff this map is empty.
+func (m *StringMap) Empty() bool { return m.pairs.Empty() }
+
+// Contains returns true just in case its argument v is a value
+// held in a key-value pair in the map.
+func (m *StringMap) Contains(v interface{}) bool { return m.pairs.Contains(v) }
+
+// Apply invokes function f on every value (not key) in the map.
+func (m *StringMap) Apply(f func(interface{})) { m.pairs.Apply(f) }
+
+// Insert puts a pair <k,v> into the map. It replaces any pair
+// with the same key <k,w> if it is already there.
+// Precondition: k is a string.
+// Precondition violation: panic.
+func (m *StringMap) Insert(k, v interface{}) {
+	m.pairs.Insert(containers.StringKey(k.(string)), v)
+}
+
+// Delete removes a pair <k,v> from the map given its key k.
+// It does nothing if the key is not in the map.
+// Precondition: k is a string.
+// Precondition violation: panic.
+func (m *StringMap) Delete(k interface{}) {
+	m.pairs.Delete(containers.StringKey(k.(string)))
+}
+
+// Get retrieves a value by its key.
+// Precondition: k is a string in the map.
+// Precondition violation: panic if k is not a string; return nil, false
+// if it is not in the map.
+// Normal return: return the value mapped to the key and true
+func (m *StringMap) Get(k interface{}) (interface{}, bool) {
+	return m.pairs.Get(containers.StringKey(k.(string)))
+}
+
+// HasKey returns true just in case the map contains a key-value pair
+// with key k.
+// Precondition: k is a string.
+// Precondition violation: panic.
+func (m *StringMap) HasKey(k interface{}) bool {
+	return m.pairs.HasKey(containers.StringKey(k.(string)))
+}
+
+// IsEqual returns true just in case the receiver map contains
+// exactly the same elements as the argument map n.
+func (m *StringMap) IsEqual(n Map) bool {
+	return m.Size() == n.Size() && isSubmap(m, n)
+}
+
+// KeyDiff returns the number of keys in the receiver map but not in n,
+// and the number of keys in n but not in the receiver.
+func (m *StringMap) KeyDiff(n Map) (onlyInReceiver, onlyInOther int) {
+	return keyDiff(m, n)
+}
+
+// Invert returns a new StringMap mapping each value in the receiver to its
+// key. If several keys have the same value, one of them (which one is not
+// specified) becomes that value's key in the result.
+// Precondition: the values are strings.
+// Precondition violation: panic.
+func (m *StringMap) Invert() Map {
+	return invertInto(new(StringMap), m)
+}
+
+// FindKeysByValue returns every key whose value is v (compared using ==),
+// in key iteration order. It must look at every pair in the map.
+func (m *StringMap) FindKeysByValue(v interface{}) []interface{} {
+	return findKeysByValue(m, v)
+}
+
+// IsSubmap returns true just in case every pair <k,v> in the receiver
+// is also in n.
+func (m *StringMap) IsSubmap(n Map) bool {
+	return isSubmap(m, n)
+}
+
+// SharedKeys returns the keys present in both the receiver and n, in the
+// receiver's key iteration order.
+func (m *StringMap) SharedKeys(n Map) []interface{} {
+	return sharedKeys(m, n)
+}
+
+// NewIterator creates and returns a new external iterator that
+// traverses values (not keys) in the map.
+func (m *StringMap) NewIterator() containers.Iterator {
+	return m.pairs.NewIterator()
+}
+
+// NewKeyIterator creates and returns a new external iterator that
+// traverses keys (not values) in the map; each key it returns is a string.
+func (m *StringMap) NewKeyIterator() containers.Iterator {
+	return &stringMapIterator{m.pairs.NewKeyIterator()}
+}
+
+// NewEntryIterator creates and returns a new external iterator that
+// traverses key-value pairs in the map; each value it returns is an Entry
+// with a string Key.
+func (m *StringMap) NewEntryIterator() containers.Iterator {
+	return &stringMapIterator{m.pairs.NewEntryIterator()}
+}
+
+// StringMap Iterator /////////////////////////////////////////////////////
+// stringMapIterator wraps an iterator over the keys or entries of the
+// underlying HashMap, converting each StringKey it meets back to a string.
+type stringMapIterator struct {
+	pairsIter containers.Iterator // iterator over the HashMap keys or entries
+}
+
+// Reset prepares for a new iteration.
+func (iter *stringMapIterator) Reset() { iter.pairsIter.Reset() }
+
+// Done returns true iff iteration is complete.
+func (iter *stringMapIterator) Done() bool { return iter.pairsIter.Done() }
+
+// Next returns the next key or entry in the iteration.
+// Precondition: Iteration is not complete.
+// Precondition violation: return nil and false.
+// Normal return: return the key as a string, or the Entry with a string Key,
+// and true.
+func (iter *stringMapIterator) Next() (interface{}, bool) {
+	e, ok := iter.pairsIter.Next()
+	if !ok {
+		return nil, false
+	}
+	if entry, isEntry := e.(Entry); isEntry {
+		return Entry{string(entry.Key.(containers.StringKey)), entry.Value}, true
+	}
+	return string(e.(containers.StringKey)), true
+}
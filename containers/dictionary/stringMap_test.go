@@ -0,0 +1,94 @@
+// Test StringMap
+
+package dictionary
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestStringMap(t *testing.T) {
+	var m Map = new(StringMap)
+	if !m.Empty() || m.Size() != 0 || m.HasKey("apple") {
+		t.Error("new StringMap should be empty")
+	}
+	m.Delete("apple") // no panic
+	fruit := map[string]int{"apple": 1, "banana": 2, "cherry": 3, "date": 4}
+	for k, v := range fruit {
+		m.Insert(k, v)
+	}
+	if m.Size() != len(fruit) {
+		t.Errorf("StringMap should have size %v but has %v", len(fruit), m.Size())
+	}
+	for k, v := range fruit {
+		if w, ok := m.Get(k); !ok || w != v {
+			t.Errorf("Get(%q) should be %v, true but is %v, %v", k, v, w, ok)
+		}
+	}
+	if w, ok := m.Get("fig"); ok || w != nil {
+		t.Errorf("Get of a missing key should be nil, false but is %v, %v", w, ok)
+	}
+	if !m.Contains(3) || m.Contains(5) {
+		t.Error("Contains should find only the values in the map")
+	}
+	m.Insert("apple", 10)
+	if w, _ := m.Get("apple"); w != 10 || m.Size() != len(fruit) {
+		t.Errorf("Insert should replace the value of apple but Get gives %v and size is %v", w, m.Size())
+	}
+	m.Delete("banana")
+	if m.HasKey("banana") || m.Size() != len(fruit)-1 {
+		t.Error("Delete should remove banana")
+	}
+
+	keys := []string{}
+	iter := m.NewKeyIterator()
+	for k, ok := iter.Next(); ok; k, ok = iter.Next() {
+		keys = append(keys, k.(string))
+	}
+	sort.Strings(keys)
+	if len(keys) != 3 || keys[0] != "apple" || keys[1] != "cherry" || keys[2] != "date" {
+		t.Errorf("key iteration should give apple, cherry, and date but gives %v", keys)
+	}
+	sum := 0
+	iter = m.(*StringMap).NewEntryIterator()
+	for e, ok := iter.Next(); ok; e, ok = iter.Next() {
+		entry := e.(Entry)
+		if w, _ := m.Get(entry.Key.(string)); w != entry.Value {
+			t.Errorf("entry %v does not match Get(%q) = %v", entry, entry.Key, w)
+		}
+		sum += entry.Value.(int)
+	}
+	if sum != 17 {
+		t.Errorf("entry values should sum to 17 but sum to %v", sum)
+	}
+
+	n := new(StringMap)
+	n.Insert("date", 4)
+	n.Insert("cherry", 3)
+	if !n.IsSubmap(m) || m.IsEqual(n) {
+		t.Error("n should be a proper submap of m")
+	}
+	n.Insert("apple", 10)
+	if !m.IsEqual(n) || !n.IsEqual(m) {
+		t.Error("m and n should be equal")
+	}
+	if only, other := m.KeyDiff(n); only != 0 || other != 0 {
+		t.Errorf("KeyDiff of equal maps should be 0, 0 but is %v, %v", only, other)
+	}
+	if keys := m.FindKeysByValue(3); len(keys) != 1 || keys[0] != "cherry" {
+		t.Errorf("FindKeysByValue(3) should be [cherry] but is %v", keys)
+	}
+
+	names := new(StringMap)
+	names.Insert("one", "uno")
+	names.Insert("two", "dos")
+	inverse := names.Invert()
+	if k, ok := inverse.Get("dos"); !ok || k != "two" || inverse.Size() != 2 {
+		t.Errorf("Invert should map dos to two but maps it to %v, %v", k, ok)
+	}
+
+	m.Clear()
+	if !m.Empty() || m.HasKey("apple") {
+		t.Error("StringMap should be empty after Clear")
+	}
+}
@@ -1,6 +1,6 @@
 // hashTable.go: Implementation of hash tables for use in sets and maps.
-// This implementation uses chaining and is not dynamic (that is, once the
-// table size is set, it does not change).
+// This implementation uses chaining and is dynamic: when the load factor
+// exceeds MaxLoadFactor, the table is rehashed into one about twice as big.
 //
 // author: C. Fox
 // version: 8/2012
@@ -11,10 +11,12 @@ package hashtbl
 
 import (
 	"containers"
+	"fmt"
 	"math"
 )
 
 const DefaultTableSize = 991 // how big the make the hash table by default
+const MaxLoadFactor = 0.75   // how full the table may get before it grows
 
 // These hash tables use chaining, so the hash table is an array of list heads
 // whose nodes are tableNodes.
@@ -34,15 +36,17 @@ func newTableNode(key containers.Hasher, value interface{}, link *tableNode) *ta
 
 // HashTable is the data structure for a hash table instance.
 type HashTable struct {
-	tableSize int          // how many slots in the table
-	count     int          // how many values are stored in the table
-	table     []*tableNode // the hash table itself
+	tableSize int                              // how many slots in the table
+	count     int                              // how many values are stored in the table
+	table     []*tableNode                     // the hash table itself
+	hashFunc  func(containers.Hasher, int) int // replaces key.Hash if not nil
 }
 
 // Create and return a new empty hash table with an optionally specified
 // tableSize. The hash table size will be the first prime number >= tableSize
 // if tableSize is specified and is at least 3; otherwise it will be
-// DefaultTableSize.
+// DefaultTableSize. The table grows as needed, so tableSize is only the
+// initial size.
 func NewHashTable(tableSize ...int) *HashTable {
 	result := new(HashTable)
 	result.tableSize = DefaultTableSize
@@ -53,6 +57,15 @@ func NewHashTable(tableSize ...int) *HashTable {
 	return result
 }
 
+// init allocates the table of a zero-value HashTable, which has no slots,
+// so that the zero value is ready to use.
+func (t *HashTable) init() {
+	if t.tableSize < 3 {
+		t.tableSize = DefaultTableSize
+	}
+	t.table = make([]*tableNode, t.tableSize)
+}
+
 // Empty returns true iff this hash table is empty.
 func (t *HashTable) Empty() bool { return t.count == 0 }
 
@@ -76,10 +89,10 @@ func (t *HashTable) Clear() {
 // Precondition violation: return nil, false.
 // Normal return: return valuev, true.
 func (t *HashTable) Get(key containers.Hasher) (interface{}, bool) {
-	if t.tableSize < 3 {
-		t.Clear()
+	if t.table == nil {
+		return nil, false
 	}
-	node := t.table[key.Hash(t.tableSize)]
+	node := t.table[t.hash(key, t.tableSize)]
 	for node != nil {
 		if node.key.Equal(key) {
 			return node.value, true
@@ -91,10 +104,10 @@ func (t *HashTable) Get(key containers.Hasher) (interface{}, bool) {
 
 // Insert puts v into the table, or replaces v if its is already there.
 func (t *HashTable) Insert(key containers.Hasher, value interface{}) {
-	if t.tableSize < 3 {
-		t.Clear()
+	if t.table == nil {
+		t.init()
 	}
-	index := key.Hash(t.tableSize)
+	index := t.hash(key, t.tableSize)
 	node := t.table[index]
 	for node != nil {
 		if node.key.Equal(key) {
@@ -105,14 +118,92 @@ func (t *HashTable) Insert(key containers.Hasher, value interface{}) {
 	}
 	t.table[index] = newTableNode(key, value, t.table[index])
 	t.count++
+	if MaxLoadFactor < float64(t.count)/float64(t.tableSize) {
+		t.rehash(nextPrime(2 * t.tableSize))
+	}
+}
+
+// AverageChainLength returns the mean number of nodes per non-empty bucket,
+// which is the expected number of key comparisons in a successful search.
+// It is 0 for an empty table, near 1 for well-distributed keys, and Size()
+// if every key collides.
+func (t *HashTable) AverageChainLength() float64 {
+	buckets := 0
+	for _, node := range t.table {
+		if node != nil {
+			buckets++
+		}
+	}
+	if buckets == 0 {
+		return 0
+	}
+	return float64(t.count) / float64(buckets)
+}
+
+// Verify checks the consistency of the table, returning an error describing
+// the first problem found, or nil if there is none. It checks that every key
+// is in the bucket it hashes to, that no key appears more than once, and
+// that the number of nodes agrees with the count.
+func (t *HashTable) Verify() error {
+	nodes := 0
+	for index, node := range t.table {
+		for ; node != nil; node = node.next {
+			if h := t.hash(node.key, t.tableSize); h != index {
+				return fmt.Errorf("Verify: key %v is in bucket %v but hashes to %v", node.key, index, h)
+			}
+			for other := node.next; other != nil; other = other.next {
+				if other.key.Equal(node.key) {
+					return fmt.Errorf("Verify: key %v appears more than once", node.key)
+				}
+			}
+			nodes++
+		}
+	}
+	if nodes != t.count {
+		return fmt.Errorf("Verify: table has %v nodes but count is %v", nodes, t.count)
+	}
+	return nil
+}
+
+// RehashWith moves every node into the bucket given by hashFunc and uses
+// hashFunc rather than the keys' Hash methods from then on. Passing nil
+// goes back to using the keys' Hash methods.
+func (t *HashTable) RehashWith(hashFunc func(containers.Hasher, int) int) {
+	t.hashFunc = hashFunc
+	if t.table != nil {
+		t.rehash(t.tableSize)
+	}
+}
+
+// hash computes the bucket for key in a table with tableSize slots.
+func (t *HashTable) hash(key containers.Hasher, tableSize int) int {
+	if t.hashFunc != nil {
+		return t.hashFunc(key, tableSize)
+	}
+	return key.Hash(tableSize)
+}
+
+// rehash moves every node into a new table with newSize slots.
+func (t *HashTable) rehash(newSize int) {
+	newTable := make([]*tableNode, newSize)
+	for _, node := range t.table {
+		for node != nil {
+			next := node.next
+			index := t.hash(node.key, newSize)
+			node.next = newTable[index]
+			newTable[index] = node
+			node = next
+		}
+	}
+	t.tableSize, t.table = newSize, newTable
 }
 
 // Delete removes v from the table, or does nothing if it is not there.
 func (t *HashTable) Delete(key containers.Hasher) {
-	if t.tableSize < 3 {
-		t.Clear()
+	if t.table == nil {
+		return
 	}
-	index := key.Hash(t.tableSize)
+	index := t.hash(key, t.tableSize)
 	node := t.table[index]
 	if node == nil {
 		return
@@ -241,6 +332,66 @@ func (t *HashTable) NewKeyIterator() containers.Iterator {
 	return result
 }
 
+/////////////////////////////////////////////////////////////////////////////
+// Entry is a key-value pair returned by entry iterators.
+type Entry struct {
+	Key   containers.Hasher // key of the pair
+	Value interface{}       // value that goes with the key
+}
+
+// hashTableEntryIterator keeps track of where we are in a table during iteration.
+type hashTableEntryIterator struct {
+	table []*tableNode // reference to the table traversed
+	index int          // table index for the next value
+	node  *tableNode   // pointer to the node for the next value
+}
+
+// Reset prepares for a new iteration.
+func (iter *hashTableEntryIterator) Reset() {
+	for iter.index = 0; iter.index < len(iter.table); iter.index++ {
+		iter.node = iter.table[iter.index]
+		if iter.node != nil {
+			break
+		}
+	}
+}
+
+// Done returns true iff iteration is complete.
+func (iter *hashTableEntryIterator) Done() bool {
+	return iter.node == nil
+}
+
+// Next returns the next key-value pair in the iteration as an Entry.
+// Precondition: there is a next value.
+// Precondition violation: return nil and false.
+// Normal return: return the next Entry and true.
+func (iter *hashTableEntryIterator) Next() (interface{}, bool) {
+	if iter.node == nil {
+		return nil, false
+	}
+	result := Entry{iter.node.key, iter.node.value}
+	iter.node = iter.node.next
+	if iter.node == nil {
+		iter.index++
+		for ; iter.index < len(iter.table); iter.index++ {
+			iter.node = iter.table[iter.index]
+			if iter.node != nil {
+				break
+			}
+		}
+	}
+	return result, true
+}
+
+// NewEntryIterator creates and returns a new external iterator over the
+// key-value pairs in the table; each value it returns is an Entry.
+func (t *HashTable) NewEntryIterator() containers.Iterator {
+	result := new(hashTableEntryIterator)
+	result.table = t.table
+	result.Reset()
+	return result
+}
+
 /////////////////////////////////////////////////////////////
 // Helper functions /////////////////////////////////////////
 
@@ -7,8 +7,10 @@ package hashtbl
 
 import (
 	//"fmt"
+	"math/rand"
 	"testing"
-	//"containers"
+
+	"containers"
 )
 
 type Integer int
@@ -168,3 +170,239 @@ func TestNonEmptyHashTable(t *testing.T) {
 		t.Errorf("HashTable should be empty and size should be 0 after clear is called")
 	}
 }
+
+func TestHashTableGrowth(t *testing.T) {
+	table := NewHashTable(5)
+	const n = 5000
+	for i := 0; i < n; i++ {
+		table.Insert(Integer(i), i)
+	}
+	if table.Size() != n {
+		t.Errorf("HashTable should have %v elements but has %v", n, table.Size())
+	}
+	if table.TableSize() <= 5 {
+		t.Errorf("HashTable should have grown but its size is %v", table.TableSize())
+	}
+	if load := float64(table.Size()) / float64(table.TableSize()); MaxLoadFactor < load {
+		t.Errorf("HashTable load factor %v exceeds %v", load, MaxLoadFactor)
+	}
+	if !isPrime(table.TableSize()) {
+		t.Errorf("HashTable size %v should be prime", table.TableSize())
+	}
+	for i := 0; i < n; i++ {
+		if v, ok := table.Get(Integer(i)); !ok || v != i {
+			t.Errorf("HashTable lost key %v after growing", i)
+		}
+	}
+	count := 0
+	for iter := table.NewKeyIterator(); !iter.Done(); iter.Next() {
+		count++
+	}
+	if count != n {
+		t.Errorf("HashTable iteration after growing found %v keys instead of %v", count, n)
+	}
+}
+
+func TestHashTableEntryIterator(t *testing.T) {
+	table := NewHashTable(7)
+	for iter := table.NewEntryIterator(); !iter.Done(); iter.Next() {
+		t.Error("Empty HashTable should not do entry iteration")
+	}
+	names := []string{"zero", "one", "two", "three", "four", "five", "six", "seven", "eight"}
+	for i, name := range names {
+		table.Insert(Integer(i), name)
+	}
+	table.Delete(Integer(4))
+	count := 0
+	iter := table.NewEntryIterator()
+	for e, ok := iter.Next(); ok; e, ok = iter.Next() {
+		entry, isEntry := e.(Entry)
+		if !isEntry {
+			t.Fatalf("Entry iterator returned %v, which is not an Entry", e)
+		}
+		if entry.Value != names[int(entry.Key.(Integer))] {
+			t.Errorf("Entry key %v should go with %v but goes with %v",
+				entry.Key, names[int(entry.Key.(Integer))], entry.Value)
+		}
+		if v, _ := table.Get(entry.Key); v != entry.Value {
+			t.Errorf("Entry %v disagrees with Get, which returned %v", entry, v)
+		}
+		count++
+	}
+	if count != table.Size() {
+		t.Errorf("Entry iterator returned %v entries but table size is %v", count, table.Size())
+	}
+	if !iter.Done() {
+		t.Error("Entry iterator should be done")
+	}
+}
+
+func TestHashTableVerify(t *testing.T) {
+	table := NewHashTable(7)
+	if err := table.Verify(); err != nil {
+		t.Errorf("Empty HashTable should verify but got %v", err)
+	}
+
+	// heavy churn, checked against a Go map
+	r := rand.New(rand.NewSource(1))
+	present := make(map[Integer]bool)
+	for i := 0; i < 5000; i++ {
+		key := Integer(r.Intn(500))
+		if r.Intn(3) == 0 {
+			table.Delete(key)
+			delete(present, key)
+		} else {
+			table.Insert(key, i)
+			present[key] = true
+		}
+		if i%100 == 0 {
+			if err := table.Verify(); err != nil {
+				t.Fatalf("HashTable failed verification after %v operations: %v", i, err)
+			}
+		}
+	}
+	if err := table.Verify(); err != nil {
+		t.Errorf("HashTable failed verification after churn: %v", err)
+	}
+	if table.Size() != len(present) {
+		t.Errorf("HashTable size should be %v but is %v", len(present), table.Size())
+	}
+	for key := range present {
+		if _, ok := table.Get(key); !ok {
+			t.Errorf("HashTable lost key %v during churn", key)
+		}
+	}
+
+	// corrupt the table in various ways
+	table = NewHashTable(7)
+	table.Insert(Integer(3), "three")
+	table.count++
+	if table.Verify() == nil {
+		t.Error("HashTable with a wrong count should fail verification")
+	}
+	table.table[3] = newTableNode(Integer(3), "again", table.table[3])
+	if table.Verify() == nil {
+		t.Error("HashTable with a duplicate key should fail verification")
+	}
+	table = NewHashTable(7)
+	table.table[0] = newTableNode(Integer(3), "three", nil)
+	table.count = 1
+	if table.Verify() == nil {
+		t.Error("HashTable with a key in the wrong bucket should fail verification")
+	}
+}
+
+func TestZeroValueHashTable(t *testing.T) {
+	var table HashTable
+	if _, ok := table.Get(Integer(5)); ok {
+		t.Error("Zero value HashTable should not retrieve anything")
+	}
+	table.Delete(Integer(5)) // no panic
+	for iter := table.NewIterator(); !iter.Done(); iter.Next() {
+		t.Error("Zero value HashTable should not do value iteration")
+	}
+	table.Insert(Integer(5), "five")
+	table.Insert(Integer(7), "seven")
+	if table.Size() != 2 {
+		t.Errorf("Zero value HashTable should have 2 elements but has %v", table.Size())
+	}
+	if table.TableSize() != DefaultTableSize {
+		t.Errorf("Zero value HashTable size should be %v but is %v", DefaultTableSize, table.TableSize())
+	}
+	if v, ok := table.Get(Integer(5)); !ok || v != "five" {
+		t.Error("Zero value HashTable should store inserted values")
+	}
+	table.Delete(Integer(5))
+	if v, ok := table.Get(Integer(7)); !ok || v != "seven" || table.Size() != 1 {
+		t.Error("Zero value HashTable should keep values after a deletion")
+	}
+	if err := table.Verify(); err != nil {
+		t.Errorf("Zero value HashTable failed verification: %v", err)
+	}
+}
+
+// Collider is a Hasher whose values all hash to the same bucket.
+type Collider int
+
+func (key Collider) Equal(other interface{}) bool { return key == other.(Collider) }
+func (key Collider) Hash(tableSize int) int       { return 0 }
+
+func TestHashTableAverageChainLength(t *testing.T) {
+	table := NewHashTable(101)
+	if n := table.AverageChainLength(); n != 0 {
+		t.Errorf("Empty HashTable average chain length should be 0 but is %v", n)
+	}
+	for i := 0; i < 50; i++ {
+		table.Insert(Integer(i), i)
+	}
+	if n := table.AverageChainLength(); n != 1 {
+		t.Errorf("Well-distributed HashTable average chain length should be 1 but is %v", n)
+	}
+	table.Insert(Integer(101), 101)
+	if n := table.AverageChainLength(); n != 51.0/50.0 {
+		t.Errorf("HashTable average chain length should be %v but is %v", 51.0/50.0, n)
+	}
+
+	table = NewHashTable(101)
+	for i := 0; i < 50; i++ {
+		table.Insert(Collider(i), i)
+	}
+	if n := table.AverageChainLength(); n != 50 {
+		t.Errorf("All-collide HashTable average chain length should be 50 but is %v", n)
+	}
+}
+
+func TestHashTableRehashWith(t *testing.T) {
+	table := NewHashTable(101)
+	const n = 60
+	for i := 0; i < n; i++ {
+		table.Insert(Integer(i), i)
+	}
+
+	// a reversing hash function moves keys into different buckets
+	reverse := func(key containers.Hasher, tableSize int) int {
+		return tableSize - 1 - key.Hash(tableSize)
+	}
+	table.RehashWith(reverse)
+	if table.table[100] == nil || table.table[100].key != Integer(0) {
+		t.Error("RehashWith should move key 0 to the last bucket")
+	}
+	if err := table.Verify(); err != nil {
+		t.Errorf("HashTable failed verification after RehashWith: %v", err)
+	}
+	for i := 0; i < n; i++ {
+		if v, ok := table.Get(Integer(i)); !ok || v != i {
+			t.Errorf("HashTable lost key %v after RehashWith", i)
+		}
+	}
+
+	// the new function is used for later insertions, deletions, and growth
+	table.Insert(Integer(n), n)
+	if table.table[100-n] == nil || table.table[100-n].key != Integer(n) {
+		t.Errorf("HashTable should insert key %v with the new hash function", n)
+	}
+	table.Delete(Integer(0))
+	if _, ok := table.Get(Integer(0)); ok || table.Size() != n {
+		t.Error("HashTable should delete keys with the new hash function")
+	}
+	for i := n + 1; i < 1000; i++ {
+		table.Insert(Integer(i), i)
+	}
+	if err := table.Verify(); err != nil {
+		t.Errorf("HashTable failed verification after growing: %v", err)
+	}
+
+	// nil goes back to the keys' hash functions
+	table.RehashWith(nil)
+	if err := table.Verify(); err != nil {
+		t.Errorf("HashTable failed verification after RehashWith(nil): %v", err)
+	}
+	if node := table.table[Integer(5).Hash(table.TableSize())]; node == nil {
+		t.Error("RehashWith(nil) should use the key hash function")
+	}
+	for i := 1; i < 1000; i++ {
+		if v, ok := table.Get(Integer(i)); !ok || v != i {
+			t.Errorf("HashTable lost key %v after RehashWith(nil)", i)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+// openHashTable.go: Implementation of open addressing hash tables for use
+// in sets and maps. This implementation uses linear probing with tombstones
+// marking deleted slots, and is dynamic: when the fraction of slots in use
+// (including tombstones) exceeds MaxOpenLoadFactor, the table is rehashed.
+//
+// author: C. Fox
+// version: 6/2017
+
+package hashtbl
+
+import (
+	"containers"
+)
+
+const MaxOpenLoadFactor = 0.5 // how full an open table may get before rehashing
+
+// Slots in an open hash table are empty, hold a key-value pair, or are
+// tombstones left behind by deletions so that probe sequences stay intact.
+const (
+	emptySlot = iota
+	occupiedSlot
+	deletedSlot
+)
+
+// openSlot is a single slot in an open hash table.
+type openSlot struct {
+	key   containers.Hasher // key used to locate key-value pair
+	value interface{}       // value that goes with the key
+	state int               // emptySlot, occupiedSlot, or deletedSlot
+}
+
+// OpenHashTable is the data structure for an open addressing hash table
+// instance. Its zero value is an empty table ready to use.
+type OpenHashTable struct {
+	tableSize  int        // how many slots in the table
+	count      int        // how many values are stored in the table
+	tombstones int        // how many slots are marked deleted
+	table      []openSlot // the hash table itself
+}
+
+// Create and return a new empty open hash table with an optionally specified
+// tableSize. The hash table size will be the first prime number >= tableSize
+// if tableSize is specified and is at least 3; otherwise it will be
+// DefaultTableSize. The table grows as needed, so tableSize is only the
+// initial size.
+func NewOpenHashTable(tableSize ...int) *OpenHashTable {
+	result := new(OpenHashTable)
+	result.tableSize = DefaultTableSize
+	if 0 < len(tableSize) && 2 < tableSize[0] {
+		result.tableSize = nextPrime(tableSize[0])
+	}
+	result.table = make([]openSlot, result.tableSize)
+	return result
+}
+
+// Empty returns true iff this hash table is empty.
+func (t *OpenHashTable) Empty() bool { return t.count == 0 }
+
+// TableSize returns the number of slots in the hash table.
+func (t *OpenHashTable) TableSize() int { return t.tableSize }
+
+// Size returns the number of values in the hash table.
+func (t *OpenHashTable) Size() int { return t.count }
+
+// Clear makes the hash table empty.
+func (t *OpenHashTable) Clear() {
+	if t.tableSize < 3 {
+		t.tableSize = DefaultTableSize
+	}
+	t.table = make([]openSlot, t.tableSize)
+	t.count = 0
+	t.tombstones = 0
+}
+
+// Get retrieves a value from a from a table given its key.
+// Precondition: key is in the table.
+// Precondition violation: return nil, false.
+// Normal return: return value, true.
+func (t *OpenHashTable) Get(key containers.Hasher) (interface{}, bool) {
+	if index, ok := t.find(key); ok {
+		return t.table[index].value, true
+	}
+	return nil, false
+}
+
+// Insert puts v into the table, or replaces v if its is already there.
+func (t *OpenHashTable) Insert(key containers.Hasher, value interface{}) {
+	if t.tableSize < 3 {
+		t.Clear()
+	}
+	index := key.Hash(t.tableSize)
+	tombstone := -1
+	for t.table[index].state != emptySlot {
+		slot := &t.table[index]
+		if slot.state == occupiedSlot && slot.key.Equal(key) {
+			slot.value = value
+			return
+		}
+		if slot.state == deletedSlot && tombstone < 0 {
+			tombstone = index
+		}
+		index = (index + 1) % t.tableSize
+	}
+	if 0 <= tombstone {
+		index = tombstone
+		t.tombstones--
+	}
+	t.table[index] = openSlot{key, value, occupiedSlot}
+	t.count++
+	if MaxOpenLoadFactor < float64(t.count+t.tombstones)/float64(t.tableSize) {
+		t.rehash()
+	}
+}
+
+// Delete removes v from the table, or does nothing if it is not there.
+func (t *OpenHashTable) Delete(key containers.Hasher) {
+	if index, ok := t.find(key); ok {
+		t.table[index] = openSlot{state: deletedSlot}
+		t.count--
+		t.tombstones++
+	}
+}
+
+// find returns the index of the slot holding key and true, or -1 and
+// false if the key is not in the table.
+func (t *OpenHashTable) find(key containers.Hasher) (int, bool) {
+	if t.count == 0 {
+		return -1, false
+	}
+	index := key.Hash(t.tableSize)
+	for t.table[index].state != emptySlot {
+		slot := &t.table[index]
+		if slot.state == occupiedSlot && slot.key.Equal(key) {
+			return index, true
+		}
+		index = (index + 1) % t.tableSize
+	}
+	return -1, false
+}
+
+// rehash moves every pair into a new table without tombstones. The table
+// doubles in size unless most of the used slots were tombstones.
+func (t *OpenHashTable) rehash() {
+	newSize := t.tableSize
+	if MaxOpenLoadFactor/2 < float64(t.count)/float64(t.tableSize) {
+		newSize = nextPrime(2 * t.tableSize)
+	}
+	oldTable := t.table
+	t.tableSize = newSize
+	t.table = make([]openSlot, newSize)
+	t.tombstones = 0
+	for _, slot := range oldTable {
+		if slot.state == occupiedSlot {
+			index := slot.key.Hash(newSize)
+			for t.table[index].state != emptySlot {
+				index = (index + 1) % newSize
+			}
+			t.table[index] = slot
+		}
+	}
+}
+
+/////////////////////////////////////////////////////////////////////////////
+// openHashTableIterator keeps track of where we are in a table during
+// iteration; extract determines whether values, keys, or entries are returned.
+type openHashTableIterator struct {
+	table   []openSlot                       // reference to the table traversed
+	index   int                              // table index for the next value
+	extract func(slot *openSlot) interface{} // what to return for each slot
+}
+
+// Reset prepares for a new iteration.
+func (iter *openHashTableIterator) Reset() {
+	iter.index = 0
+	iter.skipUnoccupied()
+}
+
+// Done returns true iff iteration is complete.
+func (iter *openHashTableIterator) Done() bool {
+	return len(iter.table) <= iter.index
+}
+
+// Next returns the next value, key, or entry in the iteration.
+// Precondition: there is a next value.
+// Precondition violation: return nil and false.
+// Normal return: return the next value and true.
+func (iter *openHashTableIterator) Next() (interface{}, bool) {
+	if iter.Done() {
+		return nil, false
+	}
+	result := iter.extract(&iter.table[iter.index])
+	iter.index++
+	iter.skipUnoccupied()
+	return result, true
+}
+
+// skipUnoccupied advances the index to the next occupied slot, if any.
+func (iter *openHashTableIterator) skipUnoccupied() {
+	for iter.index < len(iter.table) && iter.table[iter.index].state != occupiedSlot {
+		iter.index++
+	}
+}
+
+// newIterator creates and returns a new external iterator using extract.
+func (t *OpenHashTable) newIterator(extract func(slot *openSlot) interface{}) containers.Iterator {
+	result := new(openHashTableIterator)
+	result.table = t.table
+	result.extract = extract
+	result.Reset()
+	return result
+}
+
+// NewIterator creates and returns a new external value iterator.
+func (t *OpenHashTable) NewIterator() containers.Iterator {
+	return t.newIterator(func(slot *openSlot) interface{} { return slot.value })
+}
+
+// NewKeyIterator creates and returns a new external key iterator.
+func (t *OpenHashTable) NewKeyIterator() containers.Iterator {
+	return t.newIterator(func(slot *openSlot) interface{} { return slot.key })
+}
+
+// NewEntryIterator creates and returns a new external iterator over the
+// key-value pairs in the table; each value it returns is an Entry.
+func (t *OpenHashTable) NewEntryIterator() containers.Iterator {
+	return t.newIterator(func(slot *openSlot) interface{} {
+		return Entry{slot.key, slot.value}
+	})
+}
@@ -0,0 +1,246 @@
+// Test OpenHashTable implementation.
+//
+// author: C. Fox
+// version: 6/2017
+
+package hashtbl
+
+import (
+	"testing"
+
+	"containers"
+)
+
+// table is the method set shared by the hash table implementations.
+type table interface {
+	Empty() bool
+	Size() int
+	TableSize() int
+	Clear()
+	Get(key containers.Hasher) (interface{}, bool)
+	Insert(key containers.Hasher, value interface{})
+	Delete(key containers.Hasher)
+	NewIterator() containers.Iterator
+	NewKeyIterator() containers.Iterator
+	NewEntryIterator() containers.Iterator
+}
+
+var _ table = new(HashTable)
+var _ table = new(OpenHashTable)
+
+func TestOpenHashTableCreationSize(t *testing.T) {
+	data := []struct {
+		input    int
+		expected int
+	}{{1, DefaultTableSize},
+		{2, DefaultTableSize},
+		{3, 3},
+		{4, 5},
+		{6, 7},
+		{155, 157}}
+
+	for _, d := range data {
+		size := NewOpenHashTable(d.input).TableSize()
+		if size != d.expected {
+			t.Errorf("OpenHashTable table size should be %v but is %v", d.expected, size)
+		}
+	}
+}
+
+func TestOpenHashTableCreationProperties(t *testing.T) {
+	var zero OpenHashTable
+	for _, table := range []*OpenHashTable{NewOpenHashTable(), &zero} {
+		// make sure a new OpenHashTable is empty and operations do not fail on it
+		if !table.Empty() || 0 != table.Size() {
+			t.Error("OpenHashTable should be empty and size should be 0 when new")
+		}
+		if _, ok := table.Get(Integer(5)); ok {
+			t.Error("Empty OpenHashTable should not retrieve anything")
+		}
+		table.Delete(Integer(5)) // no panic
+		for iter := table.NewIterator(); !iter.Done(); iter.Next() {
+			t.Error("Empty OpenHashTable should not do value iteration")
+		}
+		for iter := table.NewKeyIterator(); !iter.Done(); iter.Next() {
+			t.Error("Empty OpenHashTable should not do key iteration")
+		}
+		table.Clear() // no panic
+	}
+	zero.Insert(Integer(5), "five")
+	if v, ok := zero.Get(Integer(5)); !ok || v != "five" {
+		t.Error("Zero value OpenHashTable should store inserted values")
+	}
+}
+
+func TestNonEmptyOpenHashTable(t *testing.T) {
+	table := NewOpenHashTable(6)
+
+	values := []KeyValue{{0, "zero"}, {1, "one"}, {2, "two"}, {3, "three"},
+		{4, "four"}, {5, "five"}, {6, "six"}, {7, "seven"},
+		{8, "eight"}, {9, "nine"}, {10, "ten"}, {11, "eleven"},
+		{12, "twelve"}, {13, "thirteen"}, {14, "fourteen"}, {15, "fifteen"},
+		{16, "sixteen"}, {17, "seventeen"}, {18, "eighteen"}, {19, "nineteen"},
+		{20, "twenty"}}
+	for _, i := range []int{3, 5, 11, 12, 7, 4, 13, 10, 7, 16, 9, 14, 8, 15, 6} {
+		table.Insert(values[i].key, values[i].value)
+	}
+
+	if table.Empty() {
+		t.Errorf("Insertion failure: Non empty table considered empty")
+	}
+	if table.Size() != 14 {
+		t.Errorf("Insertion failure: table should have 14 elements but has %v", table.Size())
+	}
+	for _, kv := range values[3:17] {
+		if v, ok := table.Get(kv.key); !ok {
+			t.Errorf("Failed to Get %v", kv)
+		} else if kv.value != v {
+			t.Errorf("Get got the wrong value %v instead of %v", v, kv.value)
+		}
+	}
+
+	// try external iterators
+	found := make([]bool, 14)
+	iter := table.NewKeyIterator()
+	for v, ok := iter.Next(); ok; v, ok = iter.Next() {
+		index := int(v.(Integer))
+		if found[index-3] {
+			t.Errorf("Key %v returned twice by the iterator", index)
+		}
+		found[index-3] = true
+	}
+	for i := range found {
+		if !found[i] {
+			t.Errorf("Iterator did not enumerate key %v", i+3)
+		}
+	}
+	if !iter.Done() {
+		t.Errorf("Iterator should be done")
+	}
+	count := 0
+	iter = table.NewEntryIterator()
+	for e, ok := iter.Next(); ok; e, ok = iter.Next() {
+		entry := e.(Entry)
+		if entry.Value != values[int(entry.Key.(Integer))].value {
+			t.Errorf("Entry %v has the wrong value", entry)
+		}
+		count++
+	}
+	if count != 14 {
+		t.Errorf("Entry iterator should return 14 entries but returned %v", count)
+	}
+
+	// delete some data and insert some data and see that things are in order
+	table.Delete(values[2].key)
+	table.Delete(values[4].key)
+	table.Delete(values[3].key)
+	table.Delete(values[16].key)
+	table.Delete(values[15].key)
+	if table.Size() != 10 {
+		t.Errorf("OpenHashTable deletion failure: table should have 10 elements but has %v", table.Size())
+	}
+	for _, kv := range values[5:15] {
+		if v, ok := table.Get(kv.key); !ok {
+			t.Errorf("Failed to Get %v", kv)
+		} else if kv.value != v {
+			t.Errorf("Get got the wrong value %v instead of %v", v, kv)
+		}
+	}
+	table.Insert(values[4].key, values[4].value)
+	table.Insert(values[16].key, values[16].value)
+	table.Insert(values[3].key, values[3].value)
+	table.Insert(values[16].key, values[16].value)
+	table.Insert(values[15].key, values[15].value)
+	if table.Size() != 14 {
+		t.Errorf("OpenHashTable deletion failure: table should have 14 elements but has %v", table.Size())
+	}
+	for _, kv := range values[3:17] {
+		if v, ok := table.Get(kv.key); !ok {
+			t.Errorf("Failed to Get %v", kv)
+		} else if kv.value != v {
+			t.Errorf("Get got the wrong value %v instead of %v", v, kv)
+		}
+	}
+
+	// test clear
+	table.Clear()
+	if !table.Empty() || 0 != table.Size() {
+		t.Errorf("OpenHashTable should be empty and size should be 0 after clear is called")
+	}
+}
+
+func TestOpenHashTableTombstones(t *testing.T) {
+	table := NewOpenHashTable(101)
+
+	// keys 0, 101, and 202 all hash to slot 0, so they share a probe sequence
+	table.Insert(Integer(0), "a")
+	table.Insert(Integer(101), "b")
+	table.Insert(Integer(202), "c")
+	table.Delete(Integer(101))
+	if _, ok := table.Get(Integer(101)); ok {
+		t.Error("OpenHashTable should not find a deleted key")
+	}
+	if v, ok := table.Get(Integer(202)); !ok || v != "c" {
+		t.Error("OpenHashTable lost a key probed past a tombstone")
+	}
+	if table.tombstones != 1 {
+		t.Errorf("OpenHashTable should have 1 tombstone but has %v", table.tombstones)
+	}
+
+	// reinsertion reuses the tombstone slot rather than extending the probe
+	table.Insert(Integer(303), "d")
+	if table.tombstones != 0 || table.table[1].key != Integer(303) {
+		t.Error("OpenHashTable should reuse the tombstone slot on insertion")
+	}
+	table.Insert(Integer(202), "C")
+	if v, _ := table.Get(Integer(202)); v != "C" || table.Size() != 3 {
+		t.Error("OpenHashTable should replace a key found past a tombstone")
+	}
+	table.Delete(Integer(0))
+	table.Delete(Integer(303))
+	table.Insert(Integer(101), "B")
+	if v, ok := table.Get(Integer(101)); !ok || v != "B" || table.Size() != 2 {
+		t.Error("OpenHashTable should reinsert a deleted key")
+	}
+
+	// heavy churn is cleaned up by rehashing without growing
+	for i := 0; i < 1000; i++ {
+		table.Insert(Integer(1000+i), i)
+		table.Delete(Integer(1000 + i))
+	}
+	if table.TableSize() != 101 {
+		t.Errorf("OpenHashTable should not grow from churn but has size %v", table.TableSize())
+	}
+	if table.Size() != 2 {
+		t.Errorf("OpenHashTable should have 2 elements but has %v", table.Size())
+	}
+	if v, ok := table.Get(Integer(202)); !ok || v != "C" {
+		t.Error("OpenHashTable lost a key during churn")
+	}
+}
+
+func TestOpenHashTableGrowth(t *testing.T) {
+	table := NewOpenHashTable(5)
+	const n = 5000
+	for i := 0; i < n; i++ {
+		table.Insert(Integer(i), i)
+	}
+	if table.Size() != n {
+		t.Errorf("OpenHashTable should have %v elements but has %v", n, table.Size())
+	}
+	if load := float64(table.Size()) / float64(table.TableSize()); MaxOpenLoadFactor < load {
+		t.Errorf("OpenHashTable load factor %v exceeds %v", load, MaxOpenLoadFactor)
+	}
+	for i := 0; i < n; i++ {
+		if v, ok := table.Get(Integer(i)); !ok || v != i {
+			t.Errorf("OpenHashTable lost key %v after growing", i)
+		}
+	}
+	count := 0
+	for iter := table.NewIterator(); !iter.Done(); iter.Next() {
+		count++
+	}
+	if count != n {
+		t.Errorf("OpenHashTable iteration after growing found %v values instead of %v", count, n)
+	}
+}
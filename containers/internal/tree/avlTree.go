@@ -31,6 +31,11 @@ func (t *AVLTree) Height() int {
 	return t.root.height
 }
 
+// Override BinaryTree.BalanceQuality to use the overridden Height.
+func (t *AVLTree) BalanceQuality() float64 {
+	return BalanceQuality(t.Size(), t.Height())
+}
+
 // Create a new node holding value v and put it at the right spot
 // at the bottom of the binary search tree. If v is already in the tree,
 // replace the value at the node with v.
@@ -40,7 +45,7 @@ func (tree *AVLTree) Add(v containers.Comparer) {
 		tree.count = 1
 		return
 	}
-	if !tree.root.contains(v) {
+	if !tree.Contains(v) {
 		tree.count++
 	}
 	tree.root.add(v)
@@ -55,6 +60,14 @@ func (tree *AVLTree) Remove(v containers.Comparer) {
 	tree.root = tree.root.remove(v)
 }
 
+// Return true iff every node in the tree has a balance factor of -1, 0, or 1
+// and the height stored at every node matches its recomputed height. This is
+// useful for checking the tree in tests and assertions.
+func (tree *AVLTree) IsBalanced() bool {
+	_, ok := tree.root.checkAVL()
+	return ok
+}
+
 // Override the BinaryTree String method to display the balance factors
 // at each node.
 func (tree *AVLTree) String() string {
@@ -132,6 +145,25 @@ func (node *btNode) rebalance() {
 	node.setHeight()
 }
 
+// Recompute the height of the tree rooted at node (the empty tree has height
+// -1) and check the stored heights and balance factors along the way.
+func (node *btNode) checkAVL() (int, bool) {
+	if node == nil {
+		return -1, true
+	}
+	leftHeight, ok := node.left.checkAVL()
+	if !ok {
+		return 0, false
+	}
+	rightHeight, ok := node.right.checkAVL()
+	if !ok {
+		return 0, false
+	}
+	height := 1 + max(leftHeight, rightHeight)
+	balance := leftHeight - rightHeight
+	return height, height == node.height && -1 <= balance && balance <= 1
+}
+
 // Insert a value in the tree rooted at node.
 func (node *btNode) add(v containers.Comparer) {
 	switch {
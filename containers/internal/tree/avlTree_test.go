@@ -135,3 +135,71 @@ func TestNonEmptyAVLTree(t *testing.T) {
 		t.Error("AVLTree should be empty after deletions")
 	}
 }
+
+func TestAVLTreeIsBalanced(t *testing.T) {
+	var r AVLTree
+	if !r.IsBalanced() || !r.IsValidBST() {
+		t.Error("Empty AVLTree should be balanced and valid")
+	}
+	for _, k := range []int{20, 10, 5, 8, 7, 3, 15, 30, 25, 27, 18, 26} {
+		r.Add(KeyValue{k, ""})
+		if !r.IsBalanced() || !r.IsValidBST() {
+			t.Errorf("AVLTree should be balanced and valid after adding %v", k)
+		}
+	}
+	for _, k := range []int{30, 8, 20, 3, 5, 15} {
+		r.Remove(KeyValue{k, ""})
+		if !r.IsBalanced() || !r.IsValidBST() {
+			t.Errorf("AVLTree should be balanced and valid after removing %v", k)
+		}
+	}
+
+	// corrupt a stored height
+	r.root.height++
+	if r.IsBalanced() {
+		t.Error("AVLTree with a wrong height should not be balanced")
+	}
+	r.root.setHeight()
+	if !r.IsBalanced() {
+		t.Error("AVLTree should be balanced once its height is repaired")
+	}
+
+	// hang a chain off a leaf with correct heights but bad balance factors
+	node := r.root
+	for node.right != nil {
+		node = node.right
+	}
+	node.right = newAVLNode(KeyValue{100, ""}, nil, newAVLNode(KeyValue{200, ""}, nil, nil))
+	node.setHeight()
+	if r.IsBalanced() {
+		t.Error("AVLTree with a long chain should not be balanced")
+	}
+	if !r.IsValidBST() {
+		t.Error("AVLTree with a long chain should still be a valid BST")
+	}
+}
+
+func TestAVLTreeReplace(t *testing.T) {
+	var r AVLTree
+	for i := 0; i < 10; i++ {
+		r.Add(KeyValue{i, "old"})
+	}
+
+	// adding an equal value with a different payload replaces it
+	r.Add(KeyValue{4, "new"})
+	r.Add(KeyValue{0, "new"})
+	if r.Size() != 10 {
+		t.Errorf("AVLTree size should stay 10 after replacements but is %v", r.Size())
+	}
+	if v, ok := r.Get(KeyValue{4, ""}); !ok || v.(KeyValue).value != "new" {
+		t.Errorf("AVLTree should hold the replacement value but holds %v", v)
+	}
+}
+
+func TestAVLTreeBidirectionalIterator(t *testing.T) {
+	var r AVLTree
+	for i := 0; i < 50; i++ {
+		r.Add(KeyValue{(i * 17) % 50, ""})
+	}
+	checkBidirectionalIterator(t, "AVLTree", r.NewBidirectionalIterator(), 50)
+}
@@ -9,6 +9,7 @@ package tree
 
 import (
 	"containers"
+	"containers/stack"
 )
 
 // A BinarySearchTree is BinaryTree whose nodes are in order when travered in order.
@@ -113,6 +114,47 @@ func (tree *BinarySearchTree) Remove(v containers.Comparer) {
 	tree.deleteNode(target, parent)
 }
 
+// Return true iff the values in the tree are in strictly increasing order
+// when it is traversed inorder, which is the defining property of a binary
+// search tree. This is useful for checking the tree in tests and assertions.
+func (tree *BinarySearchTree) IsValidBST() bool {
+	var last containers.Comparer
+	result := true
+	tree.VisitInorder(func(e interface{}) {
+		value, ok := e.(containers.Comparer)
+		if !ok || (last != nil && !last.Less(value)) {
+			result = false
+		}
+		last = value
+	})
+	return result
+}
+
+// NewBidirectionalIterator creates and returns a new external iterator that
+// moves forward (with Next) and backward (with Prev) through the tree inorder,
+// that is, in ascending order. It starts before the smallest element.
+func (tree *BinarySearchTree) NewBidirectionalIterator() containers.BidirectionalIterator {
+	result := new(bidirectionalIterator)
+	result.path = new(stack.LinkedStack)
+	result.root = tree.root
+	result.Reset()
+	return result
+}
+
+// NewIteratorFrom creates and returns a new inorder external iterator that
+// starts at the ceiling of lo (the least value in the tree not less than lo)
+// and continues to the end of the tree. It finds the ceiling in time
+// proportional to the height of the tree, and is empty if lo exceeds every
+// value in the tree.
+func (tree *BinarySearchTree) NewIteratorFrom(lo containers.Comparer) containers.Iterator {
+	result := new(inorderIterator)
+	result.stack = new(stack.LinkedStack)
+	result.root = tree.root
+	result.lo = lo
+	result.Reset()
+	return result
+}
+
 // Helper functions ------------------------------------------------------
 
 // Remove a node from a binary search tree.
@@ -154,3 +196,102 @@ func (tree *BinarySearchTree) attach(parent, child, attached *btNode) {
 		}
 	}
 }
+
+// Bidirectional Iterator implementation -----------------------------------
+
+// This private struct keeps track of the current state of bidirectional
+// iteration. Nodes have no parent pointers, so the stack holds the path from
+// the root down to the node with the element after the iterator; moving to
+// the successor or predecessor either descends from that node or climbs the
+// path. The stack is empty when the iterator is after the last element.
+// Invariant: index is how many elements are before the iterator
+type bidirectionalIterator struct {
+	path  stack.Stack // nodes from the root to the node of the next element
+	root  *btNode     // to reset to tree root
+	index int         // how many elements precede the iterator
+}
+
+// Reset prepares for a new iteration.
+func (iterator *bidirectionalIterator) Reset() {
+	iterator.path.Clear()
+	iterator.index = 0
+	iterator.pushLeftmost(iterator.root)
+}
+
+// Done indicates whether all elements have been accessed going forward.
+func (iterator *bidirectionalIterator) Done() bool {
+	return iterator.path.Empty()
+}
+
+// Next returns the element after the iterator and moves past it.
+// Precondition: Iteration is not complete.
+// Precondition violation: nil and false.
+// Normal return: the next element and true.
+func (iterator *bidirectionalIterator) Next() (interface{}, bool) {
+	node := iterator.top()
+	if node == nil {
+		return nil, false
+	}
+	if node.right != nil {
+		iterator.pushLeftmost(node.right)
+	} else {
+		for {
+			child, _ := iterator.path.Pop()
+			parent := iterator.top()
+			if parent == nil || parent.left == child {
+				break
+			}
+		}
+	}
+	iterator.index++
+	return node.value, true
+}
+
+// Prev returns the element before the iterator and moves back past it.
+// Precondition: The iterator is not before the first element.
+// Precondition violation: nil and false.
+// Normal return: the previous element and true.
+func (iterator *bidirectionalIterator) Prev() (interface{}, bool) {
+	if iterator.index == 0 {
+		return nil, false
+	}
+	node := iterator.top()
+	switch {
+	case node == nil:
+		iterator.pushRightmost(iterator.root)
+	case node.left != nil:
+		iterator.pushRightmost(node.left)
+	default:
+		for {
+			child, _ := iterator.path.Pop()
+			if iterator.top().right == child {
+				break
+			}
+		}
+	}
+	iterator.index--
+	return iterator.top().value, true
+}
+
+// top returns the node on top of the path stack, or nil if it is empty.
+func (iterator *bidirectionalIterator) top() *btNode {
+	e, err := iterator.path.Top()
+	if err != nil {
+		return nil
+	}
+	return e.(*btNode)
+}
+
+// pushLeftmost pushes node and its chain of left descendants.
+func (iterator *bidirectionalIterator) pushLeftmost(node *btNode) {
+	for ; node != nil; node = node.left {
+		iterator.path.Push(node)
+	}
+}
+
+// pushRightmost pushes node and its chain of right descendants.
+func (iterator *bidirectionalIterator) pushRightmost(node *btNode) {
+	for ; node != nil; node = node.right {
+		iterator.path.Push(node)
+	}
+}
@@ -5,8 +5,11 @@
 package tree
 
 import (
+	"fmt"
 	"strconv"
 	"testing"
+
+	"containers"
 )
 
 ///////////////////////////////////////////////////////
@@ -151,3 +154,140 @@ func TestNonEmptyBinarySearchTree(t *testing.T) {
 		t.Error("BinarySearchTree should be empty after deletions")
 	}
 }
+
+func TestBinarySearchTreeIsValidBST(t *testing.T) {
+	var r BinarySearchTree
+	if !r.IsValidBST() {
+		t.Error("Empty BinarySearchTree should be valid")
+	}
+	for _, k := range []int{20, 10, 30, 5, 15, 25, 27, 3, 18, 26} {
+		r.Add(KeyValue{k, ""})
+	}
+	if !r.IsValidBST() {
+		t.Error("BinarySearchTree should be valid after additions")
+	}
+	r.Remove(KeyValue{20, ""})
+	r.Remove(KeyValue{5, ""})
+	if !r.IsValidBST() {
+		t.Error("BinarySearchTree should be valid after removals")
+	}
+
+	// swap the root value with a value in its left sub-tree
+	r.root.value, r.root.left.value = r.root.left.value, r.root.value
+	if r.IsValidBST() {
+		t.Error("BinarySearchTree with swapped values should not be valid")
+	}
+	r.root.value, r.root.left.value = r.root.left.value, r.root.value
+
+	// duplicate a value
+	r.root.left.value = r.root.value
+	if r.IsValidBST() {
+		t.Error("BinarySearchTree with a duplicate value should not be valid")
+	}
+}
+
+// checkBidirectionalIterator checks that iter, over a tree holding keys
+// 0..n-1, walks forward to the middle, back to the start, and then forward
+// and back across the end, returning the right keys throughout.
+func checkBidirectionalIterator(t *testing.T, name string, iter containers.BidirectionalIterator, n int) {
+	if _, ok := iter.Prev(); ok {
+		t.Error(name + ": Prev at the start should fail")
+	}
+	forward := []int{}
+	for i := 0; i < n/2; i++ {
+		e, ok := iter.Next()
+		if !ok {
+			t.Fatalf("%s: Next %v should succeed", name, i)
+		}
+		forward = append(forward, e.(KeyValue).key)
+	}
+	for i := len(forward) - 1; 0 <= i; i-- {
+		if e, ok := iter.Prev(); !ok || e.(KeyValue).key != forward[i] {
+			t.Errorf("%s: Prev back from the middle should give %v but gives %v, %v", name, forward[i], e, ok)
+		}
+	}
+	if _, ok := iter.Prev(); ok {
+		t.Error(name + ": Prev back at the start should fail")
+	}
+	for i := 0; i < n; i++ {
+		if e, ok := iter.Next(); !ok || e.(KeyValue).key != i {
+			t.Errorf("%s: Next should give %v but gives %v, %v", name, i, e, ok)
+		}
+	}
+	if !iter.Done() {
+		t.Error(name + ": iterator should be done at the end")
+	}
+	if _, ok := iter.Next(); ok {
+		t.Error(name + ": Next at the end should fail")
+	}
+	for i := n - 1; n-4 <= i; i-- {
+		if e, ok := iter.Prev(); !ok || e.(KeyValue).key != i {
+			t.Errorf("%s: Prev from the end should give %v but gives %v, %v", name, i, e, ok)
+		}
+	}
+	if e, ok := iter.Next(); !ok || e.(KeyValue).key != n-4 || iter.Done() {
+		t.Errorf("%s: Next after Prev should give %v again but gives %v, %v", name, n-4, e, ok)
+	}
+	iter.Reset()
+	if e, ok := iter.Next(); !ok || e.(KeyValue).key != 0 {
+		t.Errorf("%s: Next after Reset should give 0 but gives %v, %v", name, e, ok)
+	}
+}
+
+func TestBinarySearchTreeBidirectionalIterator(t *testing.T) {
+	var r BinarySearchTree
+	iter := r.NewBidirectionalIterator()
+	if !iter.Done() {
+		t.Error("BinarySearchTree iterator over an empty tree should be done")
+	}
+	if _, ok := iter.Prev(); ok {
+		t.Error("Prev over an empty BinarySearchTree should fail")
+	}
+	keys := []int{20, 10, 30, 5, 15, 25, 35, 0, 7, 12, 17, 22, 27, 32, 38,
+		1, 2, 3, 4, 6, 8, 9, 11, 13, 14, 16, 18, 19, 21, 23, 24, 26, 28, 29, 31, 33, 34, 36, 37, 39}
+	for _, k := range keys {
+		r.Add(KeyValue{k, ""})
+	}
+	checkBidirectionalIterator(t, "BinarySearchTree", r.NewBidirectionalIterator(), len(keys))
+}
+
+func TestNewIteratorFrom(t *testing.T) {
+	var b BinarySearchTree
+	var a AVLTree
+	for _, k := range []int{20, 10, 30, 5, 15, 25, 35, 12, 17, 27} {
+		b.Add(KeyValue{k, ""})
+		a.Add(KeyValue{k, ""})
+	}
+	tests := []struct {
+		lo       int
+		expected string
+	}{
+		{-1, "[5 10 12 15 17 20 25 27 30 35]"},
+		{5, "[5 10 12 15 17 20 25 27 30 35]"},
+		{16, "[17 20 25 27 30 35]"},
+		{20, "[20 25 27 30 35]"},
+		{26, "[27 30 35]"},
+		{35, "[35]"},
+		{36, "[]"},
+	}
+	for _, tree := range []*BinarySearchTree{&b, &a.BinarySearchTree} {
+		for _, test := range tests {
+			iter := tree.NewIteratorFrom(KeyValue{test.lo, ""})
+			for pass := 0; pass < 2; pass++ {
+				keys := []int{}
+				for e, ok := iter.Next(); ok; e, ok = iter.Next() {
+					keys = append(keys, e.(KeyValue).key)
+				}
+				if fmt.Sprint(keys) != test.expected {
+					t.Errorf("iteration from %v (pass %v) should give %v but gives %v",
+						test.lo, pass, test.expected, keys)
+				}
+				iter.Reset()
+			}
+		}
+	}
+	var empty BinarySearchTree
+	if iter := empty.NewIteratorFrom(KeyValue{0, ""}); !iter.Done() {
+		t.Error("iteration from 0 over an empty tree should be done at once")
+	}
+}
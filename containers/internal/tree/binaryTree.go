@@ -10,6 +10,7 @@ package tree
 import (
 	"errors"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 
@@ -126,6 +127,105 @@ func (tree *BinaryTree) VisitPostorder(f func(e interface{})) {
 	tree.root.visitPostorder(f)
 }
 
+// CountPathsOfLength returns the number of downward paths (from a node to one
+// of its descendants) in the tree consisting of exactly length edges. Paths of
+// length 0 are single nodes, so there are Size() of them.
+func (tree *BinaryTree) CountPathsOfLength(length int) int {
+	if length < 0 {
+		return 0
+	}
+	result := 0
+	tree.root.countPaths(length, &result)
+	return result
+}
+
+// VerticalOrder returns the values in the tree grouped by column, from the
+// leftmost column to the rightmost. The root is in column 0, and a left (right)
+// child is one column left (right) of its parent. Within a column, values are
+// listed top to bottom, and left to right at the same depth. The empty tree
+// has no columns.
+func (tree *BinaryTree) VerticalOrder() [][]interface{} {
+	if tree.root == nil {
+		return nil
+	}
+
+	// breadth-first search recording each node's horizontal distance
+	type entry struct {
+		node   *btNode
+		column int
+	}
+	columns := make(map[int][]interface{})
+	minColumn, maxColumn := 0, 0
+	queue := []entry{{tree.root, 0}}
+	for len(queue) > 0 {
+		e := queue[0]
+		queue = queue[1:]
+		columns[e.column] = append(columns[e.column], e.node.value)
+		if e.column < minColumn {
+			minColumn = e.column
+		}
+		if maxColumn < e.column {
+			maxColumn = e.column
+		}
+		if e.node.left != nil {
+			queue = append(queue, entry{e.node.left, e.column - 1})
+		}
+		if e.node.right != nil {
+			queue = append(queue, entry{e.node.right, e.column + 1})
+		}
+	}
+	result := make([][]interface{}, 0, maxColumn-minColumn+1)
+	for c := minColumn; c <= maxColumn; c++ {
+		result = append(result, columns[c])
+	}
+	return result
+}
+
+// BoundaryTraversal returns the values on the boundary of the tree in
+// anti-clockwise order, each node once: the root, then the left edge top
+// down, then the leaves left to right, then the right edge bottom up. The left
+// edge runs from the root's left child, going left when possible and right
+// otherwise, and stops before reaching a leaf; the right edge is its mirror
+// image. So a root with no left child has no left edge.
+func (tree *BinaryTree) BoundaryTraversal() []interface{} {
+	if tree.root == nil {
+		return nil
+	}
+	result := []interface{}{tree.root.value}
+	if tree.root.isLeaf() {
+		return result
+	}
+	for node := tree.root.left; node != nil && !node.isLeaf(); {
+		result = append(result, node.value)
+		if node.left != nil {
+			node = node.left
+		} else {
+			node = node.right
+		}
+	}
+	tree.root.left.visitLeaves(func(e interface{}) { result = append(result, e) })
+	tree.root.right.visitLeaves(func(e interface{}) { result = append(result, e) })
+	var rightEdge []interface{}
+	for node := tree.root.right; node != nil && !node.isLeaf(); {
+		rightEdge = append(rightEdge, node.value)
+		if node.right != nil {
+			node = node.right
+		} else {
+			node = node.left
+		}
+	}
+	for i := len(rightEdge) - 1; 0 <= i; i-- {
+		result = append(result, rightEdge[i])
+	}
+	return result
+}
+
+// BalanceQuality reports how well balanced the tree is; see the package
+// function BalanceQuality.
+func (tree *BinaryTree) BalanceQuality() float64 {
+	return BalanceQuality(tree.Size(), tree.Height())
+}
+
 // NewPreorderIterator creates and returns a new preorder external iterator.
 func (tree *BinaryTree) NewPreorderIterator() containers.Iterator {
 	result := new(preorderIterator)
@@ -160,6 +260,32 @@ func (tree *BinaryTree) String() string {
 	return result
 }
 
+// PrettyString draws the tree rotated 90 degrees counterclockwise: the root
+// is at the left margin, each right subtree is drawn above its parent, and
+// each left subtree below it, joined to the parent by branch connectors.
+// This is meant for debugging, as the shape is much easier to see than in
+// the output of String.
+func (tree *BinaryTree) PrettyString() string {
+	if tree.root == nil {
+		return "empty\n"
+	}
+	return tree.root.prettyString("", "")
+}
+
+// BalanceQuality compares the height of a tree with size nodes to the
+// height of a perfectly balanced binary tree of the same size, returning
+// height / log2(size). A value near 1 (or less, for trees with more than
+// two children per node) means the tree is well balanced; larger values
+// are worse, up to (size-1)/log2(size) for a tree that is a single path.
+// Trees with fewer than two nodes are perfectly balanced, so their
+// quality is 1.
+func BalanceQuality(size, height int) float64 {
+	if size < 2 {
+		return 1
+	}
+	return float64(height) / math.Log2(float64(size))
+}
+
 ////////////////////////////////////////////////////////////////////////////
 // binary tree node type and helper functions //////////////////////////////
 
@@ -222,6 +348,33 @@ func (node *btNode) size() int {
 	return 1 + node.left.size() + node.right.size()
 }
 
+// countPaths returns a slice whose ith element is the number of nodes at
+// depth i below this node (with this node at depth 0), truncated at depth
+// length. Along the way it adds the number of downward paths of exactly
+// length edges starting at this node to *total.
+func (node *btNode) countPaths(length int, total *int) []int {
+	if node == nil {
+		return nil
+	}
+	left := node.left.countPaths(length, total)
+	right := node.right.countPaths(length, total)
+	result := []int{1}
+	for i := 0; i < length && (i < len(left) || i < len(right)); i++ {
+		count := 0
+		if i < len(left) {
+			count += left[i]
+		}
+		if i < len(right) {
+			count += right[i]
+		}
+		result = append(result, count)
+	}
+	if length < len(result) {
+		*total += result[length]
+	}
+	return result
+}
+
 // Create a string representation of the tree rooted at this node.
 func (node *btNode) toString(indent int) string {
 	const tab = 3
@@ -245,6 +398,36 @@ func (node *btNode) toString(indent int) string {
 	return result
 }
 
+// Connectors joining nodes to their parents in PrettyString output.
+const (
+	rightConnector = "/-- "
+	leftConnector  = "\\-- "
+)
+
+// prettyString draws the subtree rooted at node, rotated, with each line
+// starting with prefix; connector is how node joins its parent ("" at the
+// root). A vertical bar continues down from a parent's connector through the
+// lines between it and its child, which lie above a left child and below a
+// right one.
+func (node *btNode) prettyString(prefix, connector string) string {
+	if node == nil {
+		return ""
+	}
+	above, below := "    ", "    "
+	switch connector {
+	case "":
+		above, below = "", ""
+	case rightConnector:
+		below = "|   "
+	case leftConnector:
+		above = "|   "
+	}
+	result := node.right.prettyString(prefix+above, rightConnector)
+	result += prefix + connector + fmt.Sprint(node.value) + "\n"
+	result += node.left.prettyString(prefix+below, leftConnector)
+	return result
+}
+
 // contains checks whether a root or its subtrees contain a value e.
 func (node *btNode) contains(e interface{}) bool {
 	if node.value == e {
@@ -292,6 +475,24 @@ func (node *btNode) visitPostorder(f func(e interface{})) {
 	f(node.value)
 }
 
+// isLeaf is true iff node has no children.
+func (node *btNode) isLeaf() bool {
+	return node.left == nil && node.right == nil
+}
+
+// visitLeaves applies f to the leaves of a tree from left to right.
+func (node *btNode) visitLeaves(f func(e interface{})) {
+	if node == nil {
+		return
+	}
+	if node.isLeaf() {
+		f(node.value)
+		return
+	}
+	node.left.visitLeaves(f)
+	node.right.visitLeaves(f)
+}
+
 // Preorder Iterator implementation -----------------------------------------
 
 // This private struct keeps track of the current state of preorder iteration.
@@ -334,10 +535,14 @@ func (iterator *preorderIterator) Next() (interface{}, bool) {
 // Inorder Iterator implementation -----------------------------------------
 
 // This private struct keeps track of the current state of inorder iteration.
+// If lo is not nil, the tree must be a search tree, and iteration starts at
+// the least value not less than lo: Reset skips over nodes with smaller values
+// (and their left subtrees) instead of deferring them.
 // Invariant: current node is stack.Top()
 type inorderIterator struct {
-	stack stack.Stack // holds deferred nodes
-	root  *btNode     // to reset to tree root
+	stack stack.Stack         // holds deferred nodes
+	root  *btNode             // to reset to tree root
+	lo    containers.Comparer // if not nil, where iteration starts
 }
 
 // Reset prepares for a new iteration.
@@ -345,6 +550,10 @@ func (iterator *inorderIterator) Reset() {
 	iterator.stack.Clear()
 	node := iterator.root
 	for node != nil {
+		if iterator.lo != nil && node.value.(containers.Comparer).Less(iterator.lo) {
+			node = node.right
+			continue
+		}
 		iterator.stack.Push(node)
 		node = node.left
 	}
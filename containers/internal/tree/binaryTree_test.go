@@ -5,6 +5,7 @@
 package tree
 
 import (
+	"math"
 	"testing"
 	//"fmt"
 
@@ -184,3 +185,181 @@ func TestNonEmptyBinaryTree(t *testing.T) {
 		t.Error("BinaryTree should be empty after Clear()")
 	}
 }
+
+func TestCountPathsOfLength(t *testing.T) {
+	var r BinaryTree
+	if n := r.CountPathsOfLength(0); n != 0 {
+		t.Errorf("Empty BinaryTree should have 0 paths of length 0 but has %v", n)
+	}
+
+	// 12 with children 8 and 6, and 6 with left child 8
+	var empty BinaryTree
+	r = buildBinaryTree(8, empty, empty)
+	r = buildBinaryTree(12, r, buildBinaryTree(6, r, empty))
+	expected := []struct{ length, count int }{{-1, 0}, {0, 4}, {1, 3}, {2, 1}, {3, 0}}
+	for _, e := range expected {
+		if n := r.CountPathsOfLength(e.length); n != e.count {
+			t.Errorf("BinaryTree should have %v paths of length %v but has %v", e.count, e.length, n)
+		}
+	}
+
+	// a complete tree of height 2: every path of length 2 starts at the root
+	leaf := buildBinaryTree(1, empty, empty)
+	sub := buildBinaryTree(2, leaf, leaf)
+	r = buildBinaryTree(3, sub, sub)
+	expected = []struct{ length, count int }{{0, 7}, {1, 6}, {2, 4}, {3, 0}}
+	for _, e := range expected {
+		if n := r.CountPathsOfLength(e.length); n != e.count {
+			t.Errorf("Complete BinaryTree should have %v paths of length %v but has %v", e.count, e.length, n)
+		}
+	}
+}
+
+func TestVerticalOrder(t *testing.T) {
+	var r BinaryTree
+	if columns := r.VerticalOrder(); len(columns) != 0 {
+		t.Errorf("Empty BinaryTree should have no columns but has %v", columns)
+	}
+
+	// 1 with children 2 and 3, 2 with children 4 and 5, 3 with children 6 and 7,
+	// and 5 with right child 8; 5 and 6 share the root's column
+	var empty BinaryTree
+	leaf := func(v int) BinaryTree { return buildBinaryTree(v, empty, empty) }
+	left := buildBinaryTree(2, leaf(4), buildBinaryTree(5, empty, leaf(8)))
+	right := buildBinaryTree(3, leaf(6), leaf(7))
+	r = buildBinaryTree(1, left, right)
+	expected := [][]interface{}{{4}, {2}, {1, 5, 6}, {3, 8}, {7}}
+	columns := r.VerticalOrder()
+	if len(columns) != len(expected) {
+		t.Fatalf("VerticalOrder should have %v columns but has %v: %v", len(expected), len(columns), columns)
+	}
+	for i, column := range columns {
+		if len(column) != len(expected[i]) {
+			t.Errorf("VerticalOrder column %v should be %v but is %v", i, expected[i], column)
+			continue
+		}
+		for j, v := range column {
+			if v != expected[i][j] {
+				t.Errorf("VerticalOrder column %v should be %v but is %v", i, expected[i], column)
+				break
+			}
+		}
+	}
+}
+
+func TestBoundaryTraversal(t *testing.T) {
+	var r BinaryTree
+	if values := r.BoundaryTraversal(); len(values) != 0 {
+		t.Errorf("Empty BinaryTree should have an empty boundary but has %v", values)
+	}
+
+	var empty BinaryTree
+	leaf := func(v int) BinaryTree { return buildBinaryTree(v, empty, empty) }
+	check := func(r BinaryTree, expected []interface{}, name string) {
+		values := r.BoundaryTraversal()
+		if len(values) != len(expected) {
+			t.Errorf("%v boundary should be %v but is %v", name, expected, values)
+			return
+		}
+		for i, v := range values {
+			if v != expected[i] {
+				t.Errorf("%v boundary should be %v but is %v", name, expected, values)
+				return
+			}
+		}
+	}
+	check(leaf(1), []interface{}{1}, "Single node")
+
+	// 1 with children 2 and 3; 2 with children 4 and 5; 5 with children 7 and 8;
+	// 3 with left child 6; 6 with children 9 and 10
+	left := buildBinaryTree(2, leaf(4), buildBinaryTree(5, leaf(7), leaf(8)))
+	right := buildBinaryTree(3, buildBinaryTree(6, leaf(9), leaf(10)), empty)
+	r = buildBinaryTree(1, left, right)
+	check(r, []interface{}{1, 2, 4, 7, 8, 9, 10, 6, 3}, "Full")
+
+	// a left chain 1-2-3-4: the edge stops above the leaf
+	chain := buildBinaryTree(2, buildBinaryTree(3, leaf(4), empty), empty)
+	check(buildBinaryTree(1, chain, empty), []interface{}{1, 2, 3, 4}, "Left chain")
+
+	// a right chain 1-2-3-4: the right edge is reported bottom up
+	chain = buildBinaryTree(2, empty, buildBinaryTree(3, empty, leaf(4)))
+	check(buildBinaryTree(1, empty, chain), []interface{}{1, 4, 3, 2}, "Right chain")
+
+	// a zigzag on the left 1-2-3-4 (left, right, left) follows right links when needed
+	chain = buildBinaryTree(2, empty, buildBinaryTree(3, leaf(4), empty))
+	check(buildBinaryTree(1, chain, empty), []interface{}{1, 2, 3, 4}, "Zigzag")
+}
+
+func TestBalanceQuality(t *testing.T) {
+	expected := []struct {
+		size, height int
+		quality      float64
+	}{
+		{0, 0, 1}, {1, 0, 1}, {2, 1, 1}, {8, 3, 1}, {4, 3, 1.5}, {16, 15, 3.75},
+	}
+	for _, e := range expected {
+		if q := BalanceQuality(e.size, e.height); math.Abs(q-e.quality) > 1e-9 {
+			t.Errorf("BalanceQuality(%v, %v) should be %v but is %v", e.size, e.height, e.quality, q)
+		}
+	}
+
+	// add the same sorted keys to each kind of search tree
+	var avl AVLTree
+	var bst BinarySearchTree
+	var twoThree TwoThreeTree
+	if q := avl.BalanceQuality(); q != 1 {
+		t.Errorf("Empty AVLTree should have balance quality 1 but has %v", q)
+	}
+	for k := 0; k < 255; k++ {
+		avl.Add(KeyValue{k, ""})
+		bst.Add(KeyValue{k, ""})
+		twoThree.Add(KeyValue{k, ""})
+	}
+	if q := avl.BalanceQuality(); q < 0.8 || 1.1 < q {
+		t.Errorf("AVLTree of sorted keys should have balance quality near 1 but has %v", q)
+	}
+	if q := twoThree.BalanceQuality(); 1 < q {
+		t.Errorf("TwoThreeTree of sorted keys should have balance quality at most 1 but has %v", q)
+	}
+	if q, max := bst.BalanceQuality(), 254/math.Log2(255); math.Abs(q-max) > 1e-9 {
+		t.Errorf("BinarySearchTree of sorted keys should have balance quality %v but has %v", max, q)
+	}
+}
+
+func TestPrettyString(t *testing.T) {
+	var empty BinaryTree
+	if s := empty.PrettyString(); s != "empty\n" {
+		t.Errorf("Empty BinaryTree should be drawn as empty but is\n%v", s)
+	}
+
+	// 20 with children 10 and 30; 10 with children 5 and 15; 15 with
+	// left child 12; 30 with right child 35
+	leaf := func(v int) BinaryTree { return buildBinaryTree(v, empty, empty) }
+	left := buildBinaryTree(10, leaf(5), buildBinaryTree(15, leaf(12), empty))
+	right := buildBinaryTree(30, empty, leaf(35))
+	r := buildBinaryTree(20, left, right)
+	expected := `    /-- 35
+/-- 30
+20
+|   /-- 15
+|   |   \-- 12
+\-- 10
+    \-- 5
+`
+	if s := r.PrettyString(); s != expected {
+		t.Errorf("BinaryTree should be drawn as\n%v\nbut is\n%v", expected, s)
+	}
+
+	var avl AVLTree
+	for _, k := range []int{1, 2, 3, 4} {
+		avl.Add(containers.IntKey(k))
+	}
+	expected = `    /-- 4
+/-- 3
+2
+\-- 1
+`
+	if s := avl.PrettyString(); s != expected {
+		t.Errorf("AVLTree should be drawn as\n%v\nbut is\n%v", expected, s)
+	}
+}
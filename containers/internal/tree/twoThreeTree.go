@@ -40,6 +40,11 @@ func (t *TwoThreeTree) Height() int {
 	return t.root.height()
 }
 
+// Determine how well balanced this tree is; see BalanceQuality.
+func (t *TwoThreeTree) BalanceQuality() float64 {
+	return BalanceQuality(t.Size(), t.Height())
+}
+
 // Return a value from the tree and true, or nil and false if it is missing.
 func (t *TwoThreeTree) Get(v containers.Comparer) (interface{}, bool) {
 	if t.root == nil {
@@ -85,6 +90,67 @@ func (t *TwoThreeTree) Remove(v containers.Comparer) {
 	}
 }
 
+// Return the smallest value in the tree and true, or nil and false if it is empty.
+func (t *TwoThreeTree) Min() (interface{}, bool) {
+	if t.root == nil {
+		return nil, false
+	}
+	return t.root.min(), true
+}
+
+// Return the largest value in the tree and true, or nil and false if it is empty.
+func (t *TwoThreeTree) Max() (interface{}, bool) {
+	if t.root == nil {
+		return nil, false
+	}
+	return t.root.max(), true
+}
+
+// Remove the smallest value from the tree and return it and true, or
+// return nil and false if the tree is empty.
+func (t *TwoThreeTree) RemoveMin() (interface{}, bool) {
+	v, ok := t.Min()
+	if !ok {
+		return nil, false
+	}
+	t.Remove(v.(containers.Comparer))
+	return v, true
+}
+
+// Remove the largest value from the tree and return it and true, or
+// return nil and false if the tree is empty.
+func (t *TwoThreeTree) RemoveMax() (interface{}, bool) {
+	v, ok := t.Max()
+	if !ok {
+		return nil, false
+	}
+	t.Remove(v.(containers.Comparer))
+	return v, true
+}
+
+// Determine whether this tree satisfies the 2-3 tree invariants: every node
+// is a 2-node or a 3-node, all leaves are at the same depth, the values are
+// in strictly increasing order when visited inorder, and the count is right.
+func (t *TwoThreeTree) IsValid() bool {
+	if t.root == nil {
+		return t.count == 0
+	}
+	if _, ok := t.root.leafDepth(); !ok {
+		return false
+	}
+	var last containers.Comparer
+	count, ordered := 0, true
+	t.root.visitInorder(func(e interface{}) {
+		value, ok := e.(containers.Comparer)
+		if !ok || (last != nil && !last.Less(value)) {
+			ordered = false
+		}
+		last = value
+		count++
+	})
+	return ordered && count == t.count
+}
+
 // Apply a visitor method to every value in the tree in order.
 // Note that in a 2-3 tree, the leftmost subtree is visited first, followed
 // by the leftmost value, followed by the middle subtree, followed (if the
@@ -174,6 +240,58 @@ func (r *twoThreeNode) height() int {
 	return 1 + r.left.height()
 }
 
+// Return the depth of the leaves below this node and true, or 0 and false
+// if some node is malformed or the leaves are not all at the same depth.
+func (r *twoThreeNode) leafDepth() (int, bool) {
+	if r.nodeType != 2 && r.nodeType != 3 {
+		return 0, false
+	}
+	if r.isLeaf() {
+		return 0, r.mid == nil
+	}
+	children := []*twoThreeNode{r.left, r.mid}
+	if r.nodeType == 3 {
+		children = append(children, r.right)
+	}
+	depth := -1
+	for _, child := range children {
+		if child == nil {
+			return 0, false
+		}
+		d, ok := child.leafDepth()
+		if !ok || (depth != -1 && d != depth) {
+			return 0, false
+		}
+		depth = d
+	}
+	return depth + 1, true
+}
+
+// Return the smallest value in the tree rooted at this node, which is
+// always the leftmost value in the leftmost leaf.
+func (r *twoThreeNode) min() interface{} {
+	for !r.isLeaf() {
+		r = r.left
+	}
+	return r.value1
+}
+
+// Return the largest value in the tree rooted at this node, which is
+// always the rightmost value in the rightmost leaf.
+func (r *twoThreeNode) max() interface{} {
+	for !r.isLeaf() {
+		if r.nodeType == 3 {
+			r = r.right
+		} else {
+			r = r.mid
+		}
+	}
+	if r.nodeType == 3 {
+		return r.value2
+	}
+	return r.value1
+}
+
 // Return a value matching a given value in the tree rooted at this node
 // and true, or nil and false if it is not present.
 func (r *twoThreeNode) get(v containers.Comparer) (interface{}, bool) {
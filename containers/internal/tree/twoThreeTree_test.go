@@ -349,3 +349,135 @@ func TestInorderIterator(t *testing.T) {
 		t.Error("Big tree iteration is broken")
 	}
 }
+
+func TestMinAndMax(t *testing.T) {
+	var r TwoThreeTree
+	if v, ok := r.Min(); ok || v != nil {
+		t.Error("Empty 2-3 tree should have no minimum")
+	}
+	if v, ok := r.Max(); ok || v != nil {
+		t.Error("Empty 2-3 tree should have no maximum")
+	}
+	if v, ok := r.RemoveMin(); ok || v != nil {
+		t.Error("Empty 2-3 tree should not allow RemoveMin")
+	}
+	if v, ok := r.RemoveMax(); ok || v != nil {
+		t.Error("Empty 2-3 tree should not allow RemoveMax")
+	}
+	r.Add(KeyValue{30, "30"})
+	if v, ok := r.Min(); !ok || v.(KeyValue).key != 30 {
+		t.Errorf("Singleton 2-3 tree minimum should be 30 but is %v", v)
+	}
+	if v, ok := r.Max(); !ok || v.(KeyValue).key != 30 {
+		t.Errorf("Singleton 2-3 tree maximum should be 30 but is %v", v)
+	}
+	r = makeTestTree()
+	if v, ok := r.Min(); !ok || v.(KeyValue).key != 10 {
+		t.Errorf("2-3 tree minimum should be 10 but is %v", v)
+	}
+	if v, ok := r.Max(); !ok || v.(KeyValue).key != 50 {
+		t.Errorf("2-3 tree maximum should be 50 but is %v", v)
+	}
+}
+
+func TestRemoveMin(t *testing.T) {
+	r := makeTestTree()
+	expected := []struct {
+		min     int
+		in, pre string
+		h, s    int
+	}{{10, "20222425273035404550", "25222024304027354550", 2, 10},
+		{20, "222425273035404550", "302522242740354550", 2, 9},
+		{22, "2425273035404550", "3025242740354550", 2, 8},
+		{24, "25273035404550", "30402527354550", 1, 7},
+		{25, "273035404550", "304027354550", 1, 6},
+		{27, "3035404550", "3545304050", 1, 5},
+		{30, "35404550", "45354050", 1, 4},
+		{35, "404550", "454050", 1, 3},
+		{40, "4550", "4550", 0, 2},
+		{45, "50", "50", 0, 1},
+		{50, "", "", 0, 0}}
+	for _, e := range expected {
+		if v, ok := r.RemoveMin(); !ok || v.(KeyValue).key != e.min {
+			t.Errorf("RemoveMin should return %v but returned %v", e.min, v)
+		}
+		shapeTest(t, r, e.in, e.pre, e.h, e.s)
+	}
+	if _, ok := r.RemoveMin(); ok {
+		t.Error("RemoveMin should fail on an emptied tree")
+	}
+}
+
+func TestRemoveMax(t *testing.T) {
+	r := makeTestTree()
+	expected := []struct {
+		max     int
+		in, pre string
+		h, s    int
+	}{{50, "10202224252730354045", "25221020243040273545", 2, 10},
+		{45, "102022242527303540", "252210202430273540", 2, 9},
+		{40, "1020222425273035", "2522102024302735", 2, 8},
+		{35, "10202224252730", "22251020242730", 1, 7},
+		{30, "102022242527", "222510202427", 1, 6},
+		{27, "1020222425", "2024102225", 1, 5},
+		{25, "10202224", "20102224", 1, 4},
+		{24, "102022", "201022", 1, 3},
+		{22, "1020", "1020", 0, 2},
+		{20, "10", "10", 0, 1},
+		{10, "", "", 0, 0}}
+	for _, e := range expected {
+		if v, ok := r.RemoveMax(); !ok || v.(KeyValue).key != e.max {
+			t.Errorf("RemoveMax should return %v but returned %v", e.max, v)
+		}
+		shapeTest(t, r, e.in, e.pre, e.h, e.s)
+	}
+	if _, ok := r.RemoveMax(); ok {
+		t.Error("RemoveMax should fail on an emptied tree")
+	}
+}
+
+func TestIsValid(t *testing.T) {
+	var r TwoThreeTree
+	if !r.IsValid() {
+		t.Error("Empty 2-3 tree should be valid")
+	}
+	r = makeTestTree()
+	if !r.IsValid() {
+		t.Error("2-3 tree should be valid after additions")
+	}
+	for _, k := range []int{25, 10, 45, 30} {
+		r.Remove(KeyValue{k, ""})
+		if !r.IsValid() {
+			t.Errorf("2-3 tree should be valid after removing %v", k)
+		}
+	}
+
+	// break the ordering of values
+	r = makeTestTree()
+	saved := r.root.value1
+	r.root.value1 = KeyValue{100, "100"}
+	if r.IsValid() {
+		t.Error("2-3 tree with values out of order should not be valid")
+	}
+	r.root.value1 = saved
+
+	// break the count
+	r.count++
+	if r.IsValid() {
+		t.Error("2-3 tree with a wrong count should not be valid")
+	}
+	r.count--
+
+	// put leaves at different depths
+	r.root.left = r.root.left.left
+	if r.IsValid() {
+		t.Error("2-3 tree with leaves at different depths should not be valid")
+	}
+
+	// make a bad node type
+	r = makeTestTree()
+	r.root.nodeType = 4
+	if r.IsValid() {
+		t.Error("2-3 tree with a bad node type should not be valid")
+	}
+}
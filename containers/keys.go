@@ -0,0 +1,61 @@
+// keys.go -- Ready-made keys for sets and maps
+// author: C. Fox
+// version: 6/2017
+//
+// IntKey and StringKey are both Comparers and Hashers, so ints and strings
+// can be stored in tree and hash sets and maps without defining new types.
+
+package containers
+
+// IntKey is an int usable as a key in any set or map.
+type IntKey int
+
+// Equal is true iff x is an IntKey with the same value.
+func (k IntKey) Equal(x interface{}) bool {
+	other, ok := x.(IntKey)
+	return ok && k == other
+}
+
+// Less is true iff the receiver is less than x.
+// Precondition: x is an IntKey.
+// Precondition violation: panic.
+func (k IntKey) Less(x interface{}) bool { return k < x.(IntKey) }
+
+// Hash maps the key into 0..(s-1), even when it is negative.
+func (k IntKey) Hash(s int) int {
+	result := int(k) % s
+	if result < 0 {
+		result += s
+	}
+	return result
+}
+
+// StringKey is a string usable as a key in any set or map.
+type StringKey string
+
+// Equal is true iff x is a StringKey with the same value.
+func (k StringKey) Equal(x interface{}) bool {
+	other, ok := x.(StringKey)
+	return ok && k == other
+}
+
+// Less is true iff the receiver precedes x in byte-wise lexicographic order.
+// Precondition: x is a StringKey.
+// Precondition violation: panic.
+func (k StringKey) Less(x interface{}) bool { return k < x.(StringKey) }
+
+const (
+	fnvOffsetBasis = 2166136261 // FNV-1a 32-bit initial hash value
+	fnvPrime       = 16777619   // FNV-1a 32-bit multiplier
+)
+
+// Hash maps the key into 0..(s-1) using the 32-bit FNV-1a hash of its
+// bytes, which spreads similar strings well, reduced modulo s.
+func (k StringKey) Hash(s int) int {
+	var h uint32 = fnvOffsetBasis
+	for i := 0; i < len(k); i++ {
+		h ^= uint32(k[i])
+		h *= fnvPrime
+	}
+	return int(h % uint32(s))
+}
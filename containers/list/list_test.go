@@ -277,3 +277,576 @@ func testList(t *testing.T, list List, name string) {
 		t.Error(name + "should be empty and size should be 0 after clear is called")
 	}
 }
+
+// record is a struct type sorted by a derived key in tests.
+type record struct {
+	name string
+	age  int
+}
+
+func TestSortByKey(t *testing.T) {
+	type sortableList interface {
+		List
+		SortByKey(key func(interface{}) int)
+	}
+	lists := []sortableList{new(ArrayList), new(LinkedList), new(SinglyLinkedList)}
+	names := []string{"ArrayList ", "LinkedList ", "SinglyLinkedList "}
+	records := []record{{"ann", 30}, {"bob", 25}, {"cat", 30}, {"dan", 20},
+		{"eve", 25}, {"fay", 30}, {"gus", 20}}
+	expected := []string{"dan", "gus", "bob", "eve", "ann", "cat", "fay"}
+	byAge := func(e interface{}) int { return e.(record).age }
+	for k, list := range lists {
+		list.SortByKey(byAge) // no panic on an empty list
+		for i, r := range records {
+			list.Insert(i, r)
+		}
+		list.Get(5) // move the cursor
+		list.SortByKey(byAge)
+		if list.Size() != len(records) {
+			t.Errorf(names[k]+"size should be %v after sorting but is %v", len(records), list.Size())
+		}
+		for i, name := range expected {
+			if e, _ := list.Get(i); e.(record).name != name {
+				t.Errorf(names[k]+"element %v should be %v after sorting but is %v", i, name, e)
+			}
+		}
+		i := 0
+		iter := list.NewIterator()
+		for e, ok := iter.Next(); ok; e, ok = iter.Next() {
+			if e.(record).name != expected[i] {
+				t.Errorf(names[k]+"iterator value %v should be %v after sorting but is %v", i, expected[i], e)
+			}
+			i++
+		}
+
+		// sorting by a different key keeps the previous order among equal keys
+		list.SortByKey(func(e interface{}) int { return len(e.(record).name) - e.(record).age/30 })
+		for i, name := range []string{"ann", "cat", "fay", "dan", "gus", "bob", "eve"} {
+			if e, _ := list.Get(i); e.(record).name != name {
+				t.Errorf(names[k]+"element %v should be %v after resorting but is %v", i, name, e)
+			}
+		}
+	}
+}
+
+func TestLongestRun(t *testing.T) {
+	data := []struct {
+		values []int
+		value  interface{}
+		length int
+	}{{[]int{}, nil, 0},
+		{[]int{7}, 7, 1},
+		{[]int{1, 2, 3, 4, 5}, 1, 1},
+		{[]int{4, 4, 4, 4}, 4, 4},
+		{[]int{1, 2, 2, 3, 3, 3, 3, 2, 2, 2, 1}, 3, 4},
+		{[]int{5, 5, 1, 6, 6, 2, 2}, 5, 2},
+		{[]int{1, 9, 9, 9}, 9, 3}}
+	for _, list := range []List{new(ArrayList), new(LinkedList), new(SinglyLinkedList)} {
+		for _, d := range data {
+			list.Clear()
+			for i, v := range d.values {
+				list.Insert(i, v)
+			}
+			if v, n := list.LongestRun(); v != d.value || n != d.length {
+				t.Errorf("%T longest run in %v should be %v of length %v but is %v of length %v",
+					list, d.values, d.value, d.length, v, n)
+			}
+		}
+
+		// uncomparable elements are compared deeply
+		list.Clear()
+		for i, v := range [][]int{{1}, {2, 3}, {2, 3}, {4}} {
+			list.Insert(i, v)
+		}
+		if v, n := list.LongestRun(); fmt.Sprint(v) != "[2 3]" || n != 2 {
+			t.Errorf("%T longest run of []ints should be [2 3] of length 2 but is %v of length %v", list, v, n)
+		}
+	}
+}
+
+func TestMapToSlice(t *testing.T) {
+	for _, list := range []List{new(ArrayList), new(LinkedList), new(SinglyLinkedList)} {
+		toString := func(e interface{}) interface{} { return fmt.Sprint(e) }
+		if result := list.MapToSlice(toString); len(result) != 0 {
+			t.Errorf("%T MapToSlice of an empty list should be empty but is %v", list, result)
+		}
+		for i, v := range []int{3, 14, 15, 92, 6} {
+			list.Insert(i, v)
+		}
+		result := list.MapToSlice(toString)
+		expected := []string{"3", "14", "15", "92", "6"}
+		if len(result) != len(expected) {
+			t.Errorf("%T MapToSlice should have %v values but has %v", list, len(expected), len(result))
+			continue
+		}
+		for i := range expected {
+			if result[i] != expected[i] {
+				t.Errorf("%T MapToSlice should be %v but is %v", list, expected, result)
+				break
+			}
+		}
+		if list.Size() != len(expected) {
+			t.Errorf("%T MapToSlice should not change the list", list)
+		}
+	}
+}
+
+func TestResize(t *testing.T) {
+	for _, list := range []List{new(ArrayList), new(LinkedList), new(SinglyLinkedList)} {
+		if err := list.Resize(-1, 0); err == nil {
+			t.Errorf("%T Resize should fail on a negative length", list)
+		}
+
+		// grow an empty list, then a short one
+		list.Resize(2, "x")
+		list.Insert(1, "a")
+		if err := list.Resize(6, "y"); err != nil {
+			t.Errorf("%T Resize to 6 failed: %v", list, err)
+		}
+		expected := []interface{}{"x", "a", "x", "y", "y", "y"}
+		if !sameElements(list, expected) {
+			t.Errorf("%T Resize to 6 should give %v but gives %v", list, expected, list.MapToSlice(identity))
+		}
+
+		// the equal-length case changes nothing
+		list.Resize(6, "z")
+		if !sameElements(list, expected) {
+			t.Errorf("%T Resize to its own length should change nothing but gives %v", list, list.MapToSlice(identity))
+		}
+
+		// shrink, and check the list still works afterwards
+		list.Resize(3, "z")
+		if !sameElements(list, expected[:3]) {
+			t.Errorf("%T Resize to 3 should give %v but gives %v", list, expected[:3], list.MapToSlice(identity))
+		}
+		list.Insert(3, "b")
+		list.Insert(0, "c")
+		if e, _ := list.Get(4); list.Size() != 5 || e != "b" {
+			t.Errorf("%T should append after Resize but gives %v", list, list.MapToSlice(identity))
+		}
+		list.Resize(0, "z")
+		if !list.Empty() {
+			t.Errorf("%T Resize to 0 should empty the list but gives %v", list, list.MapToSlice(identity))
+		}
+		list.Insert(0, "d")
+		if e, _ := list.Get(0); list.Size() != 1 || e != "d" {
+			t.Errorf("%T should insert after Resize to 0 but gives %v", list, list.MapToSlice(identity))
+		}
+	}
+}
+
+// identity returns its argument.
+func identity(e interface{}) interface{} { return e }
+
+// sameElements is true iff list holds exactly the values in expected, in order.
+func sameElements(list List, expected []interface{}) bool {
+	values := list.MapToSlice(identity)
+	if len(values) != len(expected) {
+		return false
+	}
+	for i := range values {
+		if values[i] != expected[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestCartesianProduct(t *testing.T) {
+	for _, a := range []List{new(ArrayList), new(LinkedList), new(SinglyLinkedList)} {
+		b := new(LinkedList)
+		if p := CartesianProduct(a, b); !p.Empty() {
+			t.Errorf("CartesianProduct of empty %T lists should be empty but has size %v", a, p.Size())
+		}
+		for i, e := range []interface{}{1, 2, 3} {
+			a.Insert(i, e)
+		}
+		if p := CartesianProduct(a, b); !p.Empty() {
+			t.Errorf("CartesianProduct of a %T with an empty list should be empty but has size %v", a, p.Size())
+		}
+		b.Insert(0, "x")
+		b.Insert(1, "y")
+		p := CartesianProduct(a, b)
+		if p.Size() != a.Size()*b.Size() {
+			t.Errorf("CartesianProduct of a %T should have size %v but has size %v", a, a.Size()*b.Size(), p.Size())
+		}
+		expected := []interface{}{
+			Pair{1, "x"}, Pair{1, "y"}, Pair{2, "x"}, Pair{2, "y"}, Pair{3, "x"}, Pair{3, "y"},
+		}
+		if !sameElements(p, expected) {
+			t.Errorf("CartesianProduct of a %T should be %v but is %v", a, expected, p.MapToSlice(identity))
+		}
+		if p := CartesianProduct(b, a); p.Size() != 6 {
+			t.Errorf("CartesianProduct with a %T should have size 6 but has size %v", a, p.Size())
+		} else if e, _ := p.Get(1); e != (Pair{"x", 2}) {
+			t.Errorf("CartesianProduct with a %T should have second pair {x 2} but has %v", a, e)
+		}
+	}
+}
+
+// KeyValue is a test element whose identity is its key.
+type KeyValue struct {
+	key   int
+	value []string // makes KeyValues uncomparable
+}
+
+func TestIndexFunc(t *testing.T) {
+	hasKey := func(k int) func(interface{}) bool {
+		return func(e interface{}) bool { return e.(KeyValue).key == k }
+	}
+	for _, list := range []List{new(ArrayList), new(LinkedList), new(SinglyLinkedList)} {
+		if list.ContainsFunc(hasKey(1)) {
+			t.Errorf("Empty %T should not contain anything", list)
+		}
+		if i, ok := list.IndexFunc(hasKey(1)); ok {
+			t.Errorf("Empty %T should not have an index for anything but gives %v", list, i)
+		}
+		for i, k := range []int{3, 1, 4, 1, 5} {
+			list.Insert(i, KeyValue{k, []string{"value", fmt.Sprint(i)}})
+		}
+		for _, e := range []struct{ key, index int }{{3, 0}, {1, 1}, {4, 2}, {5, 4}} {
+			if !list.ContainsFunc(hasKey(e.key)) {
+				t.Errorf("%T should contain key %v", list, e.key)
+			}
+			if i, ok := list.IndexFunc(hasKey(e.key)); !ok || i != e.index {
+				t.Errorf("%T should have key %v at index %v but gives %v, %v", list, e.key, e.index, i, ok)
+			}
+		}
+		if list.ContainsFunc(hasKey(9)) {
+			t.Errorf("%T should not contain key 9", list)
+		}
+		if i, ok := list.IndexFunc(hasKey(9)); ok || i != 0 {
+			t.Errorf("%T should not have an index for key 9 but gives %v, %v", list, i, ok)
+		}
+	}
+}
+
+func TestSwap(t *testing.T) {
+	for _, list := range []List{new(ArrayList), new(LinkedList), new(SinglyLinkedList)} {
+		if err := list.Swap(0, 0); err == nil {
+			t.Errorf("Empty %T Swap should fail", list)
+		}
+		for i := 0; i < 5; i++ {
+			list.Insert(i, i)
+		}
+		for _, bad := range [][2]int{{-1, 0}, {0, -1}, {5, 0}, {0, 5}, {7, 7}} {
+			if err := list.Swap(bad[0], bad[1]); err == nil {
+				t.Errorf("%T Swap(%v, %v) should fail", list, bad[0], bad[1])
+			}
+		}
+		if !sameElements(list, []interface{}{0, 1, 2, 3, 4}) {
+			t.Errorf("%T failed Swaps should change nothing but give %v", list, list.MapToSlice(identity))
+		}
+		if err := list.Swap(2, 2); err != nil || !sameElements(list, []interface{}{0, 1, 2, 3, 4}) {
+			t.Errorf("%T Swap(2, 2) should change nothing but gives %v, %v", list, list.MapToSlice(identity), err)
+		}
+		list.Swap(0, 4)
+		list.Swap(3, 1)
+		list.Swap(1, 2)
+		expected := []interface{}{4, 2, 3, 1, 0}
+		if !sameElements(list, expected) {
+			t.Errorf("%T Swaps should give %v but give %v", list, expected, list.MapToSlice(identity))
+		}
+		for i, e := range expected {
+			if v, _ := list.Get(i); v != e {
+				t.Errorf("%T Get(%v) after Swaps should be %v but is %v", list, i, e, v)
+			}
+		}
+	}
+}
+
+func TestEqualFunc(t *testing.T) {
+	sameInts := func(a, b interface{}) bool {
+		x, y := a.([]int), b.([]int)
+		if len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if x[i] != y[i] {
+				return false
+			}
+		}
+		return true
+	}
+	lists := []func() List{
+		func() List { return new(ArrayList) },
+		func() List { return new(LinkedList) },
+		func() List { return new(SinglyLinkedList) },
+	}
+	for _, newL := range lists {
+		for _, newM := range lists {
+			l, m := newL(), newM()
+			if !l.EqualFunc(m, sameInts) || !l.Equal(m) {
+				t.Errorf("Empty %T and %T should be equal", l, m)
+			}
+			for i := 0; i < 3; i++ {
+				l.Insert(i, []int{i, i + 1})
+				m.Insert(i, []int{i, i + 1})
+			}
+			if !l.EqualFunc(m, sameInts) {
+				t.Errorf("%T and %T holding equal []ints should be EqualFunc", l, m)
+			}
+			if !l.Equal(m) { // falls back on deep equality
+				t.Errorf("%T and %T holding equal []ints should be Equal", l, m)
+			}
+			if !l.Contains([]int{1, 2}) || l.Contains([]int{1, 3}) {
+				t.Errorf("%T Contains is wrong for []int elements", l)
+			}
+			if i, ok := l.Index([]int{2, 3}); !ok || i != 2 {
+				t.Errorf("%T Index of [2 3] should be 2 but is %v, %v", l, i, ok)
+			}
+			// Pair is comparable, but == panics when its fields hold []ints
+			l.Insert(3, Pair{1, []int{1}})
+			m.Insert(3, Pair{1, []int{1}})
+			if !l.Equal(m) || !l.Contains(Pair{1, []int{1}}) || l.Contains(Pair{1, []int{2}}) {
+				t.Errorf("%T and %T holding Pairs of []ints should be compared deeply", l, m)
+			}
+			if i, ok := l.Index(Pair{1, []int{1}}); !ok || i != 3 {
+				t.Errorf("%T Index of a Pair holding a []int should be 3 but is %v, %v", l, i, ok)
+			}
+			l.Delete(3)
+			m.Delete(3)
+			m.Put(1, []int{1, 5})
+			if l.EqualFunc(m, sameInts) || l.Equal(m) {
+				t.Errorf("%T and %T with different []ints should not be equal", l, m)
+			}
+			m.Delete(1)
+			if l.EqualFunc(m, sameInts) {
+				t.Errorf("%T and %T of different sizes should not be equal", l, m)
+			}
+		}
+	}
+}
+
+func TestCount(t *testing.T) {
+	list := new(ArrayList)
+	for i, v := range []int{3, 1, 4, 1, 5, 9, 2, 6} {
+		list.Insert(i, v)
+	}
+	isOdd := func(e interface{}) bool { return e.(int)%2 == 1 }
+	if count := containers.Count(list, isOdd); count != 5 {
+		t.Errorf("Count of odds in an ArrayList should be 5 but is %v", count)
+	}
+	if !containers.Any(list, isOdd) || containers.All(list, isOdd) {
+		t.Error("ArrayList should have some but not all odds")
+	}
+}
+
+func TestToSlice(t *testing.T) {
+	for _, list := range []List{new(ArrayList), new(LinkedList), new(SinglyLinkedList)} {
+		if s := containers.ToSlice(list); len(s) != 0 {
+			t.Errorf("ToSlice of an empty %T should be empty but is %v", list, s)
+		}
+		for i, v := range []string{"a", "b", "c"} {
+			list.Insert(i, v)
+		}
+		if s := containers.ToSlice(list); fmt.Sprint(s) != "[a b c]" {
+			t.Errorf("ToSlice of a %T should be [a b c] but is %v", list, s)
+		}
+	}
+}
+
+func TestEqualOrdered(t *testing.T) {
+	a, b := new(ArrayList), new(LinkedList)
+	for i, v := range []int{1, 2, 3} {
+		a.Insert(i, v)
+		b.Insert(i, v)
+	}
+	if !containers.EqualOrdered(a, b) || !containers.EqualUnordered(a, b) {
+		t.Error("Lists with the same elements in the same order should be equal")
+	}
+	b.Swap(0, 2)
+	if containers.EqualOrdered(a, b) {
+		t.Error("Lists with elements in different orders should not be EqualOrdered")
+	}
+	if !containers.EqualUnordered(a, b) {
+		t.Error("Lists with the same elements in different orders should be EqualUnordered")
+	}
+	b.Put(0, 4)
+	if containers.EqualOrdered(a, b) || containers.EqualUnordered(a, b) {
+		t.Error("Lists with different elements should not be equal")
+	}
+
+	// uncomparable elements are compared deeply
+	a.Clear()
+	b.Clear()
+	for i, v := range [][]int{{1}, {2, 3}} {
+		a.Insert(i, v)
+		b.Insert(i, append([]int(nil), v...))
+	}
+	if !containers.EqualOrdered(a, b) {
+		t.Error("Lists with equal []int elements should be EqualOrdered")
+	}
+	b.Put(1, []int{3, 2})
+	if containers.EqualOrdered(a, b) {
+		t.Error("Lists with different []int elements should not be EqualOrdered")
+	}
+}
+
+func TestSinglyLinkedListTail(t *testing.T) {
+	list := new(SinglyLinkedList)
+	checkTail := func(op string) {
+		var last *snode
+		for ptr := list.head; ptr != nil; ptr = ptr.next {
+			last = ptr
+		}
+		if list.tail != last {
+			t.Errorf("SinglyLinkedList tail is wrong after %v", op)
+		}
+	}
+	checkTail("creation")
+	for i := 0; i < 5; i++ {
+		list.Insert(list.Size(), i)
+		list.Get(0) // move the cursor away from the end
+		checkTail("append")
+	}
+	list.Insert(0, -1)
+	list.Insert(3, 10)
+	checkTail("insertions before the end")
+	list.Delete(list.Size() - 1)
+	checkTail("deleting the last element")
+	list.Delete(0)
+	list.Delete(2)
+	checkTail("deleting other elements")
+	list.Insert(list.Size(), 5)
+	if fmt.Sprint(containers.ToSlice(list)) != "[0 1 2 3 5]" {
+		t.Errorf("SinglyLinkedList should be [0 1 2 3 5] but is %v", containers.ToSlice(list))
+	}
+	list.Resize(2, nil)
+	checkTail("shrinking")
+	list.Resize(4, 7)
+	checkTail("growing")
+	if fmt.Sprint(containers.ToSlice(list)) != "[0 1 7 7]" {
+		t.Errorf("SinglyLinkedList should be [0 1 7 7] but is %v", containers.ToSlice(list))
+	}
+	for !list.Empty() {
+		list.Delete(list.Size() - 1)
+		checkTail("deleting from the end")
+	}
+	list.Insert(0, 1)
+	list.Clear()
+	checkTail("Clear")
+	list.Insert(0, 2)
+	checkTail("inserting into an empty list")
+}
+
+// Each append is preceded by a Get(0), which moves the cursor to the head, so
+// without a tail pointer each append would walk the whole list.
+func BenchmarkSinglyLinkedListAppend(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		list := new(SinglyLinkedList)
+		for j := 0; j < 10000; j++ {
+			list.Get(0)
+			list.Insert(list.Size(), j)
+		}
+	}
+}
+
+func TestTrimToSize(t *testing.T) {
+	list := new(ArrayList)
+	for i := 0; i < 1000; i++ {
+		list.Insert(i, i)
+	}
+	for list.Size() > 10 {
+		list.Delete(list.Size() - 1)
+	}
+	list.TrimToSize()
+	if cap(list.store) != list.Size() {
+		t.Errorf("TrimToSize should leave capacity %v but leaves %v", list.Size(), cap(list.store))
+	}
+	for i := 0; i < 10; i++ {
+		if v, err := list.Get(i); err != nil || v != i {
+			t.Errorf("after TrimToSize Get(%v) should be %v but is %v, %v", i, i, v, err)
+		}
+	}
+	list.Insert(10, 10)
+	if v, err := list.Get(10); err != nil || v != 10 || list.Size() != 11 {
+		t.Errorf("Insert after TrimToSize failed: size %v, Get(10) is %v, %v", list.Size(), v, err)
+	}
+	list.Clear()
+	list.TrimToSize()
+	if cap(list.store) != 0 {
+		t.Errorf("TrimToSize on an empty list should leave capacity 0 but leaves %v", cap(list.store))
+	}
+}
+
+func TestLinkedListNodeReuse(t *testing.T) {
+	list := new(LinkedList)
+	for i := 0; i < 5; i++ {
+		list.Insert(i, i)
+	}
+	for i := 0; i < 3; i++ {
+		list.Delete(1)
+	}
+	if len(list.pool) != 3 {
+		t.Errorf("Delete should put 3 nodes in the pool but it holds %v", len(list.pool))
+	}
+	for i := 1; i <= 3; i++ {
+		list.Insert(i, i*10)
+	}
+	if len(list.pool) != 0 {
+		t.Error("Insert should take nodes from the pool")
+	}
+	expected := []interface{}{0, 10, 20, 30, 4}
+	if got := containers.ToSlice(list); fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Errorf("after reusing nodes list should be %v but is %v", expected, got)
+	}
+	list.Clear()
+	if len(list.pool) != 0 {
+		t.Error("Clear should empty the pool")
+	}
+
+	// the pool holds at most nodePoolSize nodes
+	for i := 0; i < 2*nodePoolSize; i++ {
+		list.Insert(i, i)
+	}
+	for !list.Empty() {
+		list.Delete(0)
+	}
+	if len(list.pool) != nodePoolSize {
+		t.Errorf("pool should be capped at %v nodes but holds %v", nodePoolSize, len(list.pool))
+	}
+	list.Clear()
+
+	// an iterator resting on a deleted node carries on through the list
+	for i := 0; i < 5; i++ {
+		list.Insert(i, i)
+	}
+	iter := list.NewIterator()
+	iter.Next()
+	list.Delete(1)
+	got := []interface{}{}
+	for e, ok := iter.Next(); ok; e, ok = iter.Next() {
+		got = append(got, e)
+	}
+	if fmt.Sprint(got) != "[1 2 3 4]" {
+		t.Errorf("iterator across Delete should give [1 2 3 4] but gives %v", got)
+	}
+
+	// an iterator resting on a deleted node that is reused stops
+	iter = list.NewIterator()
+	iter.Next()
+	list.Delete(1)
+	list.Insert(3, 99)
+	if !iter.Done() {
+		t.Error("iterator resting on a reused node should be done")
+	}
+	if e, ok := iter.Next(); ok {
+		t.Errorf("iterator resting on a reused node should stop but gives %v", e)
+	}
+}
+
+// Repeatedly filling and emptying a list reuses pooled nodes rather than
+// allocating new ones; run with -benchmem to see allocations per operation.
+func BenchmarkLinkedListChurn(b *testing.B) {
+	b.ReportAllocs()
+	list := new(LinkedList)
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < nodePoolSize; j++ {
+			list.Insert(0, j)
+		}
+		for j := 0; j < nodePoolSize; j++ {
+			list.Delete(0)
+		}
+	}
+}
@@ -13,18 +13,26 @@ package list
 import (
 	"containers"
 	"fmt"
+	"slice"
 )
 
 // List is the interface for lists in the container hierarchy.
 type List interface {
-	containers.Collection              // includes Size, Clear, Empty, NewIterator, and Contains
-	Insert(i int, e interface{}) error // insert e at i; pre: 0 <= i <= Size()
-	Delete(i int) (interface{}, error) // remove and return element at i; pre: 0 <= i < Size()
-	Get(i int) (interface{}, error)    // return element at i; pre: 0 <= i < Size()
-	Put(i int, e interface{}) error    // replace element at i; pre: 0 <= i < Size()
-	Index(e interface{}) (int, bool)   // return index of e, true, or 0, false if e not present
-	Slice(i, j int) (List, error)      // return a duplicate list from i to j-1; pre: 0 <= i <= j <= Size()
-	Equal(l List) bool                 // true iff l is identical to the receiver
+	containers.Collection                                     // includes Size, Clear, Empty, NewIterator, and Contains
+	Insert(i int, e interface{}) error                        // insert e at i; pre: 0 <= i <= Size()
+	Delete(i int) (interface{}, error)                        // remove and return element at i; pre: 0 <= i < Size()
+	Get(i int) (interface{}, error)                           // return element at i; pre: 0 <= i < Size()
+	Put(i int, e interface{}) error                           // replace element at i; pre: 0 <= i < Size()
+	Swap(i, j int) error                                      // exchange elements at i and j; pre: 0 <= i, j < Size()
+	Index(e interface{}) (int, bool)                          // return index of e, true, or 0, false if e not present
+	ContainsFunc(pred func(interface{}) bool) bool            // true iff pred is true of some element
+	IndexFunc(pred func(interface{}) bool) (int, bool)        // return index of first element satisfying pred, true, or 0, false
+	Slice(i, j int) (List, error)                             // return a duplicate list from i to j-1; pre: 0 <= i <= j <= Size()
+	Equal(l List) bool                                        // true iff l is identical to the receiver
+	EqualFunc(l List, eq func(a, b interface{}) bool) bool    // true iff l is identical to the receiver, comparing with eq
+	LongestRun() (interface{}, int)                           // return the value and length of the longest run of equal elements
+	MapToSlice(f func(interface{}) interface{}) []interface{} // return f of each element, in order, in a slice
+	Resize(n int, fill interface{}) error                     // truncate or pad with fill to length n; pre: 0 <= n
 }
 
 // ArrayList is a contiguous implementation of a list.
@@ -82,7 +90,7 @@ func (list *ArrayList) Empty() bool { return list.count == 0 }
 // Contains returns true iff element e is in the list.
 func (list *ArrayList) Contains(e interface{}) bool {
 	for index := 0; index < list.count; index++ {
-		if list.store[index] == e {
+		if containers.ElementsEqual(list.store[index], e) {
 			return true
 		}
 	}
@@ -150,11 +158,44 @@ func (list *ArrayList) Put(i int, e interface{}) error {
 	return nil
 }
 
+// Swap exchanges the elements at locations i and j.
+// Precondition: 0 <= i, j < list.count.
+// Precondition violation: change nothing and return an error indication.
+// Normal return: exchange the elements and return nil.
+func (list *ArrayList) Swap(i, j int) error {
+	if i < 0 || list.count <= i {
+		return fmt.Errorf("Swap: index out of bounds: %d", i)
+	}
+	if j < 0 || list.count <= j {
+		return fmt.Errorf("Swap: index out of bounds: %d", j)
+	}
+	list.store[i], list.store[j] = list.store[j], list.store[i]
+	return nil
+}
+
 // Index returns the location of element e. If e is not present,
 // return 0 and false; otherwise return the location and true.
 func (list *ArrayList) Index(e interface{}) (int, bool) {
 	for index := 0; index < list.count; index++ {
-		if list.store[index] == e {
+		if containers.ElementsEqual(list.store[index], e) {
+			return index, true
+		}
+	}
+	return 0, false
+}
+
+// ContainsFunc returns true iff pred is true of some element of the list.
+func (list *ArrayList) ContainsFunc(pred func(interface{}) bool) bool {
+	_, ok := list.IndexFunc(pred)
+	return ok
+}
+
+// IndexFunc returns the location of the first element for which pred is
+// true. If there is none, return 0 and false; otherwise return the location
+// and true.
+func (list *ArrayList) IndexFunc(pred func(interface{}) bool) (int, bool) {
+	for index := 0; index < list.count; index++ {
+		if pred(list.store[index]) {
 			return index, true
 		}
 	}
@@ -179,18 +220,23 @@ func (list *ArrayList) Slice(i, j int) (List, error) {
 
 // Equal determines whether another List is identical to this one.
 // Two List are identical if they are the same size and have the same
-// elements in the same order.
-// Precondition: element can be compared using ==.
-// Precondition violation: panic.
-// Normal return: true iff both lists have the same elements in the same order.
+// elements in the same order. Elements are compared using
+// containers.ElementsEqual, so uncomparable elements such as slices are
+// compared deeply.
 func (list *ArrayList) Equal(l List) bool {
+	return list.EqualFunc(l, containers.ElementsEqual)
+}
+
+// EqualFunc is like Equal except that eq decides whether two elements,
+// one from each list, are the same.
+func (list *ArrayList) EqualFunc(l List, eq func(a, b interface{}) bool) bool {
 	if list.count != l.Size() {
 		return false
 	}
 	iter := l.NewIterator()
 	v, ok := iter.Next()
 	for index := 0; index < list.count; index++ {
-		if !ok || list.store[index] != v {
+		if !ok || !eq(list.store[index], v) {
 			return false
 		}
 		v, ok = iter.Next()
@@ -198,6 +244,57 @@ func (list *ArrayList) Equal(l List) bool {
 	return true
 }
 
+// SortByKey rearranges the list so its elements are in ascending order of
+// the int key that key returns for each one. The sort is stable: elements
+// with equal keys stay in the same relative order.
+func (list *ArrayList) SortByKey(key func(interface{}) int) {
+	copy(list.store, sortByKey(list.store[:list.count], key))
+}
+
+// LongestRun returns the value of the longest run of consecutive equal
+// elements in the list and its length, or nil and 0 if the list is empty.
+// If there is a tie, the first such run is reported.
+func (list *ArrayList) LongestRun() (interface{}, int) {
+	return longestRun(list.NewIterator())
+}
+
+// MapToSlice returns a slice holding f applied to each element of the list,
+// in list order.
+func (list *ArrayList) MapToSlice(f func(interface{}) interface{}) []interface{} {
+	return mapToSlice(list, f)
+}
+
+// Resize makes the list exactly n elements long by dropping elements from
+// the end or by appending copies of fill.
+// Precondition: 0 <= n.
+// Precondition violation: change nothing and return an error indication.
+// Normal return: resize the list and return nil.
+func (list *ArrayList) Resize(n int, fill interface{}) error {
+	if n < 0 {
+		return fmt.Errorf("Resize: negative length: %d", n)
+	}
+	for i := n; i < list.count; i++ {
+		list.store[i] = nil // let dropped elements be collected
+	}
+	if n < list.count {
+		list.count = n
+	}
+	for list.count < n {
+		list.Insert(list.count, fill)
+	}
+	return nil
+}
+
+// TrimToSize reallocates the list's storage to hold exactly its current
+// elements, freeing any excess capacity. Since the next insertion must then
+// reallocate again, this is only worth doing when the list has shrunk a lot
+// and is not expected to grow back soon.
+func (list *ArrayList) TrimToSize() {
+	store := make([]interface{}, list.count)
+	copy(store, list.store[:list.count])
+	list.store = store
+}
+
 // String makes a report on the data structure.
 func (list *ArrayList) String() string {
 	return fmt.Sprintf("ArrayList instance:\nsize: %d\nstore len: %d\nstore cap: %d\nstore: %v\n",
@@ -210,43 +307,62 @@ func (list *ArrayList) String() string {
 // The cursor provides faster access in long lists. The cursor is undefined
 // if cursorPtr is nil; otherwise cursorPtr point to the element with
 // index cursorIdx.
+// Up to nodePoolSize deleted nodes are kept in a pool and reused by later
+// insertions to save allocations. A pooled node is left untouched until it is
+// reused, so an iterator resting on it carries on through the live list, but
+// this also means that up to nodePoolSize deleted values stay reachable until
+// their nodes are reused or the list is cleared. Each node counts how often
+// it has been reused, so an iterator resting on a node that is then reused
+// stops instead of carrying on from the node's new place in the list.
+
+const nodePoolSize = 64 // most deleted nodes a LinkedList keeps for reuse
 
 type node struct {
-	item interface{} // data at this node
-	pred *node       // the predecessor node
-	succ *node       // the successor node
+	item   interface{} // data at this node
+	pred   *node       // the predecessor node
+	succ   *node       // the successor node
+	reuses int         // how many times this node has come from the pool
 }
 
 // LinkedList is a linked implementation of a list.
 type LinkedList struct {
-	head      *node // start of a doubly-linked list of values
-	count     int   // how many elements are in the list
-	cursorPtr *node // node where the cursor rests
-	cursorIdx int   // index where the cursor rests
+	head      *node   // start of a doubly-linked list of values
+	count     int     // how many elements are in the list
+	cursorPtr *node   // node where the cursor rests
+	cursorIdx int     // index where the cursor rests
+	pool      []*node // deleted nodes available for reuse
 }
 
 // linkedListIterator is the data structure for a LinkedList external iterator.
 type linkedListIterator struct {
 	list    *LinkedList // the list that is iterated over
 	current *node       // where we are in the list
+	reuses  int         // current.reuses when the iterator reached current
 }
 
 // Reset prepares an iterator to traverse its associated Collection.
-func (iter *linkedListIterator) Reset() { iter.current = iter.list.head }
+func (iter *linkedListIterator) Reset() {
+	iter.current = iter.list.head
+	iter.reuses = iter.current.reuses
+}
 
-// Done is true iff the iterator has traversed its associated Collection.
-func (iter *linkedListIterator) Done() bool { return iter.current == iter.list.head }
+// Done is true iff the iterator has traversed its associated Collection,
+// or the node it rests on has been reused since it got there.
+func (iter *linkedListIterator) Done() bool {
+	return iter.current == iter.list.head || iter.current.reuses != iter.reuses
+}
 
 // Next returns an interface{} and an indication of whether iteration is complete.
 // Precondition: Iteration is not complete.
 // Precondition violation: return nil and false.
 // Normal return: the next element in the iteration and true.
 func (iter *linkedListIterator) Next() (interface{}, bool) {
-	if iter.current == iter.list.head {
+	if iter.Done() {
 		return nil, false
 	}
 	result := iter.current.item
 	iter.current = iter.current.succ
+	iter.reuses = iter.current.reuses
 	return result, true
 }
 
@@ -267,6 +383,7 @@ func (list *LinkedList) Size() int { return list.count }
 func (list *LinkedList) Clear() {
 	list.count = 0
 	list.head = nil
+	list.pool = nil
 	list.init()
 }
 
@@ -279,6 +396,7 @@ func (list *LinkedList) NewIterator() containers.Iterator {
 	result := new(linkedListIterator)
 	result.list = list
 	result.current = list.head.succ
+	result.reuses = result.current.reuses
 	return result
 }
 
@@ -294,7 +412,7 @@ func (list *LinkedList) Apply(f func(interface{})) {
 func (list *LinkedList) Contains(e interface{}) bool {
 	list.init()
 	for ptr := list.head.succ; ptr != list.head; ptr = ptr.succ {
-		if ptr.item == e {
+		if containers.ElementsEqual(ptr.item, e) {
 			return true
 		}
 	}
@@ -311,7 +429,7 @@ func (list *LinkedList) Insert(i int, e interface{}) error {
 	}
 	list.init()
 	list.setCursor(i)
-	newNode := &node{e, list.cursorPtr.pred, list.cursorPtr}
+	newNode := list.newNode(e, list.cursorPtr.pred, list.cursorPtr)
 	list.cursorPtr.pred.succ = newNode
 	list.cursorPtr.pred = newNode
 	list.cursorPtr = newNode
@@ -333,7 +451,9 @@ func (list *LinkedList) Delete(i int) (interface{}, error) {
 	result = list.cursorPtr.item
 	list.cursorPtr.pred.succ = list.cursorPtr.succ
 	list.cursorPtr.succ.pred = list.cursorPtr.pred
+	deleted := list.cursorPtr
 	list.cursorPtr = list.cursorPtr.succ
+	list.freeNode(deleted)
 	list.count--
 	return result, nil
 }
@@ -365,12 +485,51 @@ func (list *LinkedList) Put(i int, e interface{}) error {
 	return nil
 }
 
+// Swap exchanges the elements at locations i and j.
+// Precondition: 0 <= i, j < list.count.
+// Precondition violation: change nothing and return an error indication.
+// Normal return: exchange the elements and return nil.
+// The nodes' items are exchanged; the nodes themselves stay where they are.
+func (list *LinkedList) Swap(i, j int) error {
+	if i < 0 || list.count <= i {
+		return fmt.Errorf("Swap: index out of bounds: %d", i)
+	}
+	if j < 0 || list.count <= j {
+		return fmt.Errorf("Swap: index out of bounds: %d", j)
+	}
+	list.init()
+	list.setCursor(i)
+	iPtr := list.cursorPtr
+	list.setCursor(j)
+	iPtr.item, list.cursorPtr.item = list.cursorPtr.item, iPtr.item
+	return nil
+}
+
 // Index returns the location of element e. If e is not present,
 // return 0 and false; otherwise return the location and true.
 func (list *LinkedList) Index(e interface{}) (int, bool) {
 	list.init()
 	for index, ptr := 0, list.head.succ; ptr != list.head; index, ptr = index+1, ptr.succ {
-		if ptr.item == e {
+		if containers.ElementsEqual(ptr.item, e) {
+			return index, true
+		}
+	}
+	return 0, false
+}
+
+// ContainsFunc returns true iff pred is true of some element of the list.
+func (list *LinkedList) ContainsFunc(pred func(interface{}) bool) bool {
+	_, ok := list.IndexFunc(pred)
+	return ok
+}
+
+// IndexFunc returns the location of the first element for which pred is
+// true. If there is none, return 0 and false; otherwise return the location
+// and true.
+func (list *LinkedList) IndexFunc(pred func(interface{}) bool) (int, bool) {
+	list.init()
+	for index, ptr := 0, list.head.succ; ptr != list.head; index, ptr = index+1, ptr.succ {
+		if pred(ptr.item) {
 			return index, true
 		}
 	}
@@ -397,11 +556,16 @@ func (list *LinkedList) Slice(i, j int) (List, error) {
 
 // Equal determines whether another List is identical to this one.
 // Two Lists are identical if they are the same size and have the same
-// elements in the same order.
-// Precondition: element can be compared using ==.
-// Precondition violation: panic.
-// Normal return: true iff both lists have the same elements in the same order.
+// elements in the same order. Elements are compared using
+// containers.ElementsEqual, so uncomparable elements such as slices are
+// compared deeply.
 func (list *LinkedList) Equal(l List) bool {
+	return list.EqualFunc(l, containers.ElementsEqual)
+}
+
+// EqualFunc is like Equal except that eq decides whether two elements,
+// one from each list, are the same.
+func (list *LinkedList) EqualFunc(l List, eq func(a, b interface{}) bool) bool {
 	if list.count != l.Size() {
 		return false
 	}
@@ -409,7 +573,7 @@ func (list *LinkedList) Equal(l List) bool {
 	iter := l.NewIterator()
 	v, ok := iter.Next()
 	for ptr := list.head.succ; ptr != list.head; ptr = ptr.succ {
-		if !ok || ptr.item != v {
+		if !ok || !eq(ptr.item, v) {
 			return false
 		}
 		v, ok = iter.Next()
@@ -417,6 +581,22 @@ func (list *LinkedList) Equal(l List) bool {
 	return true
 }
 
+// SortByKey rearranges the list so its elements are in ascending order of
+// the int key that key returns for each one. The sort is stable: elements
+// with equal keys stay in the same relative order. The elements are copied
+// to a slice to be sorted and then put back in the existing nodes.
+func (list *LinkedList) SortByKey(key func(interface{}) int) {
+	list.init()
+	items := make([]interface{}, 0, list.count)
+	for ptr := list.head.succ; ptr != list.head; ptr = ptr.succ {
+		items = append(items, ptr.item)
+	}
+	items = sortByKey(items, key)
+	for ptr, i := list.head.succ, 0; ptr != list.head; ptr, i = ptr.succ, i+1 {
+		ptr.item = items[i]
+	}
+}
+
 // abs returns the absolute value of an integer (used by setCursor).
 func abs(a int) int {
 	if a < 0 {
@@ -445,6 +625,60 @@ func (list *LinkedList) setCursor(index int) {
 	}
 }
 
+// LongestRun returns the value of the longest run of consecutive equal
+// elements in the list and its length, or nil and 0 if the list is empty.
+// If there is a tie, the first such run is reported.
+func (list *LinkedList) LongestRun() (interface{}, int) {
+	return longestRun(list.NewIterator())
+}
+
+// MapToSlice returns a slice holding f applied to each element of the list,
+// in list order.
+func (list *LinkedList) MapToSlice(f func(interface{}) interface{}) []interface{} {
+	return mapToSlice(list, f)
+}
+
+// Resize makes the list exactly n elements long by dropping elements from
+// the end or by appending copies of fill.
+// Precondition: 0 <= n.
+// Precondition violation: change nothing and return an error indication.
+// Normal return: resize the list and return nil.
+func (list *LinkedList) Resize(n int, fill interface{}) error {
+	if n < 0 {
+		return fmt.Errorf("Resize: negative length: %d", n)
+	}
+	for n < list.count {
+		list.Delete(list.count - 1)
+	}
+	for list.count < n {
+		list.Insert(list.count, fill)
+	}
+	return nil
+}
+
+// newNode returns a node holding e between pred and succ, taking it from
+// the pool if possible.
+func (list *LinkedList) newNode(e interface{}, pred, succ *node) *node {
+	last := len(list.pool) - 1
+	if last < 0 {
+		return &node{item: e, pred: pred, succ: succ}
+	}
+	result := list.pool[last]
+	list.pool[last] = nil
+	list.pool = list.pool[:last]
+	result.item, result.pred, result.succ = e, pred, succ
+	result.reuses++
+	return result
+}
+
+// freeNode puts deleted node n in the pool, unless the pool is full. Its
+// fields are not changed, so that iterators resting on it still work.
+func (list *LinkedList) freeNode(n *node) {
+	if len(list.pool) < nodePoolSize {
+		list.pool = append(list.pool, n)
+	}
+}
+
 // String makes a report on the data structure.
 func (list *LinkedList) String() string {
 	list.init()
@@ -458,3 +692,64 @@ func (list *LinkedList) String() string {
 	}
 	return result + "\n"
 }
+
+// Package functions ----------------------------------------------------
+
+// Pair holds two values, as in the lists made by CartesianProduct.
+type Pair struct {
+	First, Second interface{}
+}
+
+// CartesianProduct makes a new list holding a Pair for every combination of an
+// element of a (First) with an element of b (Second). The pairs are in order of
+// the elements of a, and for each of them, in order of the elements of b.
+func CartesianProduct(a, b List) List {
+	result := new(ArrayList)
+	a.Apply(func(x interface{}) {
+		b.Apply(func(y interface{}) {
+			result.Insert(result.Size(), Pair{x, y})
+		})
+	})
+	return result
+}
+
+// Helper functions -----------------------------------------------------
+
+// mapToSlice collects f applied to each element of list into a slice.
+func mapToSlice(list List, f func(interface{}) interface{}) []interface{} {
+	result := make([]interface{}, 0, list.Size())
+	list.Apply(func(e interface{}) { result = append(result, f(e)) })
+	return result
+}
+
+// longestRun finds the longest run of consecutive equal values produced by
+// iter, returning its value and length, or nil and 0 if iter produces nothing.
+func longestRun(iter containers.Iterator) (interface{}, int) {
+	var result, current interface{}
+	resultLength, currentLength := 0, 0
+	for e, ok := iter.Next(); ok; e, ok = iter.Next() {
+		if 0 < currentLength && containers.ElementsEqual(e, current) {
+			currentLength++
+		} else {
+			current, currentLength = e, 1
+		}
+		if resultLength < currentLength {
+			result, resultLength = current, currentLength
+		}
+	}
+	return result, resultLength
+}
+
+// sortByKey returns a new slice with the items in stably sorted ascending
+// order of their keys.
+func sortByKey(items []interface{}, key func(interface{}) int) []interface{} {
+	keys := make([]int, len(items))
+	for i, item := range items {
+		keys[i] = key(item)
+	}
+	result := make([]interface{}, len(items))
+	for i, j := range slice.MergeSortIndices(keys) {
+		result[i] = items[j]
+	}
+	return result
+}
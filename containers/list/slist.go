@@ -9,8 +9,9 @@ import (
 	"fmt"
 )
 
-// A singly-linked list with a cursor is used to store the values. A pointer is kept
-// to the head of the list. The cursor sometimes provides faster access in long lists.
+// A singly-linked list with a cursor is used to store the values. Pointers are kept
+// to the head and tail of the list; the tail pointer makes appending at the end take
+// constant time. The cursor sometimes provides faster access in long lists.
 // The cursor is undefined if cursorPtr is nil; otherwise cursorPtr point to the
 // element with index cursorIdx.
 // snode is used for the singly-linked list
@@ -23,6 +24,7 @@ type snode struct {
 type SinglyLinkedList struct {
 	count     int    // how many elements are in the list
 	head      *snode // start of a singly linked list of values
+	tail      *snode // last node in the list; nil iff the list is empty
 	cursorPtr *snode // snode where the cursor rests
 	cursorIdx int    // index where the cursor rests
 }
@@ -62,7 +64,7 @@ func (list *SinglyLinkedList) Size() int { return list.count }
 // Clear removes all elements from the list.
 func (list *SinglyLinkedList) Clear() {
 	list.count = 0
-	list.head = nil
+	list.head, list.tail = nil, nil
 	list.cursorPtr, list.cursorIdx = nil, 0
 }
 
@@ -87,7 +89,7 @@ func (list *SinglyLinkedList) Apply(f func(interface{})) {
 // Contains returns true iff element e is in the Collection.
 func (list *SinglyLinkedList) Contains(e interface{}) bool {
 	for ptr := list.head; ptr != nil; ptr = ptr.next {
-		if ptr.item == e {
+		if containers.ElementsEqual(ptr.item, e) {
 			return true
 		}
 	}
@@ -102,12 +104,21 @@ func (list *SinglyLinkedList) Insert(i int, e interface{}) error {
 	if i < 0 || list.count < i {
 		return fmt.Errorf("Insert: index out of bounds: %d", i)
 	}
-	if i == 0 {
+	switch {
+	case i == list.count:
+		newNode := &snode{e, nil}
+		if list.tail == nil {
+			list.head = newNode
+		} else {
+			list.tail.next = newNode
+		}
+		list.tail = newNode
+	case i == 0:
 		list.head = &snode{e, list.head}
 		if list.cursorPtr != nil {
 			list.cursorIdx++
 		}
-	} else {
+	default:
 		list.setCursor(i - 1)
 		list.cursorPtr.next = &snode{e, list.cursorPtr.next}
 	}
@@ -141,6 +152,9 @@ func (list *SinglyLinkedList) Delete(i int) (interface{}, error) {
 		list.setCursor(i - 1)
 		result = list.cursorPtr.next.item
 		list.cursorPtr.next = list.cursorPtr.next.next
+		if list.cursorPtr.next == nil {
+			list.tail = list.cursorPtr
+		}
 	}
 	list.count--
 	return result, nil
@@ -171,11 +185,51 @@ func (list *SinglyLinkedList) Put(i int, e interface{}) error {
 	return nil
 }
 
+// Swap exchanges the elements at locations i and j.
+// Precondition: 0 <= i, j < list.count.
+// Precondition violation: change nothing and return an error indication.
+// Normal return: exchange the elements and return nil.
+// The nodes' items are exchanged; the nodes themselves stay where they are.
+func (list *SinglyLinkedList) Swap(i, j int) error {
+	if i < 0 || list.count <= i {
+		return fmt.Errorf("Swap: index out of bounds: %d", i)
+	}
+	if j < 0 || list.count <= j {
+		return fmt.Errorf("Swap: index out of bounds: %d", j)
+	}
+	if j < i {
+		i, j = j, i
+	}
+	list.setCursor(i)
+	iPtr := list.cursorPtr
+	list.setCursor(j)
+	iPtr.item, list.cursorPtr.item = list.cursorPtr.item, iPtr.item
+	return nil
+}
+
 // Index returns the location of element e. If e is not present,
 // return 0 and false; otherwise return the location and true.
 func (list *SinglyLinkedList) Index(e interface{}) (int, bool) {
 	for index, ptr := 0, list.head; ptr != nil; index, ptr = index+1, ptr.next {
-		if ptr.item == e {
+		if containers.ElementsEqual(ptr.item, e) {
+			return index, true
+		}
+	}
+	return 0, false
+}
+
+// ContainsFunc returns true iff pred is true of some element of the list.
+func (list *SinglyLinkedList) ContainsFunc(pred func(interface{}) bool) bool {
+	_, ok := list.IndexFunc(pred)
+	return ok
+}
+
+// IndexFunc returns the location of the first element for which pred is
+// true. If there is none, return 0 and false; otherwise return the location
+// and true.
+func (list *SinglyLinkedList) IndexFunc(pred func(interface{}) bool) (int, bool) {
+	for index, ptr := 0, list.head; ptr != nil; index, ptr = index+1, ptr.next {
+		if pred(ptr.item) {
 			return index, true
 		}
 	}
@@ -201,18 +255,23 @@ func (list *SinglyLinkedList) Slice(i, j int) (List, error) {
 
 // Equal determines whether another List is identical to this one.
 // Two Lists are identical if they are the same size and have the same
-// elements in the same order.
-// Precondition: element can be compared using ==.
-// Precondition violation: panic.
-// Normal return: true iff both lists have the same elements in the same order.
+// elements in the same order. Elements are compared using
+// containers.ElementsEqual, so uncomparable elements such as slices are
+// compared deeply.
 func (list *SinglyLinkedList) Equal(l List) bool {
+	return list.EqualFunc(l, containers.ElementsEqual)
+}
+
+// EqualFunc is like Equal except that eq decides whether two elements,
+// one from each list, are the same.
+func (list *SinglyLinkedList) EqualFunc(l List, eq func(a, b interface{}) bool) bool {
 	if list.count != l.Size() {
 		return false
 	}
 	iter := l.NewIterator()
 	v, ok := iter.Next()
 	for ptr := list.head; ptr != nil; ptr = ptr.next {
-		if !ok || ptr.item != v {
+		if !ok || !eq(ptr.item, v) {
 			return false
 		}
 		v, ok = iter.Next()
@@ -220,6 +279,21 @@ func (list *SinglyLinkedList) Equal(l List) bool {
 	return true
 }
 
+// SortByKey rearranges the list so its elements are in ascending order of
+// the int key that key returns for each one. The sort is stable: elements
+// with equal keys stay in the same relative order. The elements are copied
+// to a slice to be sorted and then put back in the existing nodes.
+func (list *SinglyLinkedList) SortByKey(key func(interface{}) int) {
+	items := make([]interface{}, 0, list.count)
+	for ptr := list.head; ptr != nil; ptr = ptr.next {
+		items = append(items, ptr.item)
+	}
+	items = sortByKey(items, key)
+	for ptr, i := list.head, 0; ptr != nil; ptr, i = ptr.next, i+1 {
+		ptr.item = items[i]
+	}
+}
+
 // setCursor moves the cursor to a location taking the shortest route.
 // Precondition: i is in range.
 func (list *SinglyLinkedList) setCursor(index int) {
@@ -232,6 +306,42 @@ func (list *SinglyLinkedList) setCursor(index int) {
 	}
 }
 
+// LongestRun returns the value of the longest run of consecutive equal
+// elements in the list and its length, or nil and 0 if the list is empty.
+// If there is a tie, the first such run is reported.
+func (list *SinglyLinkedList) LongestRun() (interface{}, int) {
+	return longestRun(list.NewIterator())
+}
+
+// MapToSlice returns a slice holding f applied to each element of the list,
+// in list order.
+func (list *SinglyLinkedList) MapToSlice(f func(interface{}) interface{}) []interface{} {
+	return mapToSlice(list, f)
+}
+
+// Resize makes the list exactly n elements long by dropping elements from
+// the end or by appending copies of fill.
+// Precondition: 0 <= n.
+// Precondition violation: change nothing and return an error indication.
+// Normal return: resize the list and return nil.
+func (list *SinglyLinkedList) Resize(n int, fill interface{}) error {
+	if n < 0 {
+		return fmt.Errorf("Resize: negative length: %d", n)
+	}
+	if n == 0 {
+		list.Clear()
+	} else if n < list.count {
+		list.setCursor(n - 1)
+		list.cursorPtr.next = nil
+		list.tail = list.cursorPtr
+		list.count = n
+	}
+	for list.count < n {
+		list.Insert(list.count, fill)
+	}
+	return nil
+}
+
 // String makes a report on the container.
 func (list *SinglyLinkedList) String() string {
 	result := fmt.Sprintf("SinglyLinkedList instance:\nsize: %d\n", list.count)
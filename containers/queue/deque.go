@@ -0,0 +1,125 @@
+// deque.go -- implementation of the ArrayDeque part of the containers/queue package
+// author: C. Fox
+// version: 1/2016
+//
+// An ArrayDeque is a double-ended queue: elements may be added and removed
+// at either end. Its elements are kept in a contiguous circular buffer, so it
+// is a fast choice for double-ended workloads.
+package queue
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ArrayDeque -----------------------------------------------------------------
+// A slice is used as a circular buffer. The front element is at store[frontIndex],
+// and the rear element is at store[(frontIndex+count-1)%len(store)]. When the
+// buffer is full it is replaced by one about twice as long holding the elements
+// in order starting at index 0.
+// Invariant: len(store) >= Size()
+
+// ArrayDeque is a contiguous implementation of a deque.
+type ArrayDeque struct {
+	count      int           // how many elements are in the deque
+	frontIndex int           // store[frontIndex] is the front element
+	store      []interface{} // circular buffer for deque elements
+}
+
+// Size returns the number of elements in the deque.
+func (d *ArrayDeque) Size() int { return d.count }
+
+// Clear makes the deque empty.
+func (d *ArrayDeque) Clear() {
+	d.count, d.frontIndex = 0, 0
+	d.store = nil
+}
+
+// Empty returns true iff the deque is empty.
+func (d *ArrayDeque) Empty() bool { return d.count == 0 }
+
+// Front returns the front element of the deque without removing it.
+// Precondition: the deque is not empty.
+// Precondition violation: return nil and an error indication.
+// Normal return: the front element and nil.
+func (d *ArrayDeque) Front() (interface{}, error) {
+	if d.count == 0 {
+		return nil, errors.New("Front: the deque cannot be empty")
+	}
+	return d.store[d.frontIndex], nil
+}
+
+// Back returns the rear element of the deque without removing it.
+// Precondition: the deque is not empty.
+// Precondition violation: return nil and an error indication.
+// Normal return: the rear element and nil.
+func (d *ArrayDeque) Back() (interface{}, error) {
+	if d.count == 0 {
+		return nil, errors.New("Back: the deque cannot be empty")
+	}
+	return d.store[(d.frontIndex+d.count-1)%len(d.store)], nil
+}
+
+// PushFront adds a new element at the front of the deque.
+func (d *ArrayDeque) PushFront(e interface{}) {
+	d.makeRoom()
+	d.frontIndex = (d.frontIndex - 1 + len(d.store)) % len(d.store)
+	d.store[d.frontIndex] = e
+	d.count++
+}
+
+// PushBack adds a new element at the rear of the deque.
+func (d *ArrayDeque) PushBack(e interface{}) {
+	d.makeRoom()
+	d.store[(d.frontIndex+d.count)%len(d.store)] = e
+	d.count++
+}
+
+// PopFront removes and returns the front element of the deque.
+// Precondition: the deque is not empty.
+// Precondition violation: return nil and an error indication.
+// Normal return: the front element and nil.
+func (d *ArrayDeque) PopFront() (interface{}, error) {
+	if d.count == 0 {
+		return nil, errors.New("PopFront: the deque cannot be empty")
+	}
+	result := d.store[d.frontIndex]
+	d.store[d.frontIndex] = nil
+	d.frontIndex = (d.frontIndex + 1) % len(d.store)
+	d.count--
+	return result, nil
+}
+
+// PopBack removes and returns the rear element of the deque.
+// Precondition: the deque is not empty.
+// Precondition violation: return nil and an error indication.
+// Normal return: the rear element and nil.
+func (d *ArrayDeque) PopBack() (interface{}, error) {
+	if d.count == 0 {
+		return nil, errors.New("PopBack: the deque cannot be empty")
+	}
+	i := (d.frontIndex + d.count - 1) % len(d.store)
+	result := d.store[i]
+	d.store[i] = nil
+	d.count--
+	return result, nil
+}
+
+// makeRoom makes sure there is space in the store for another element,
+// doubling its size and moving the front element to index 0 if it is full.
+func (d *ArrayDeque) makeRoom() {
+	if d.count < len(d.store) {
+		return
+	}
+	newStore := make([]interface{}, 2*len(d.store)+4)
+	for i := 0; i < d.count; i++ {
+		newStore[i] = d.store[(d.frontIndex+i)%len(d.store)]
+	}
+	d.store, d.frontIndex = newStore, 0
+}
+
+// String makes a report on the container.
+func (d *ArrayDeque) String() string {
+	return fmt.Sprintf("ArrayDeque instance:\nsize: %d\nfrontIndex: %d\nstore len: %d\n"+
+		"store: %v\n", d.count, d.frontIndex, len(d.store), d.store)
+}
@@ -0,0 +1,125 @@
+// Test the ArrayDeque data structure.
+// author: C. Fox
+// version: 1/2016
+
+package queue
+
+import (
+	"testing"
+)
+
+func TestArrayDeque(t *testing.T) {
+	var d ArrayDeque
+	if !d.Empty() || d.Size() != 0 {
+		t.Error("ArrayDeque should be empty when new")
+	}
+	if v, err := d.Front(); err == nil {
+		t.Errorf("ArrayDeque Front should fail when empty but returns %v", v)
+	}
+	if v, err := d.Back(); err == nil {
+		t.Errorf("ArrayDeque Back should fail when empty but returns %v", v)
+	}
+	if v, err := d.PopFront(); err == nil {
+		t.Errorf("ArrayDeque PopFront should fail when empty but returns %v", v)
+	}
+	if v, err := d.PopBack(); err == nil {
+		t.Errorf("ArrayDeque PopBack should fail when empty but returns %v", v)
+	}
+
+	// push 1..20 alternately at the back and front, so the front wraps
+	// around the buffer and the buffer grows several times
+	for i := 1; i <= 20; i++ {
+		if i%2 == 0 {
+			d.PushBack(i)
+		} else {
+			d.PushFront(i)
+		}
+		if d.Size() != i {
+			t.Errorf("ArrayDeque should have size %v but has size %v", i, d.Size())
+		}
+	}
+	expected := []int{19, 17, 15, 13, 11, 9, 7, 5, 3, 1, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20}
+	if v, _ := d.Front(); v != 19 {
+		t.Errorf("ArrayDeque Front should be 19 but is %v", v)
+	}
+	if v, _ := d.Back(); v != 20 {
+		t.Errorf("ArrayDeque Back should be 20 but is %v", v)
+	}
+
+	// take some off each end, then add more to force wrapping before a resize
+	for i := 0; i < 3; i++ {
+		if v, _ := d.PopFront(); v != expected[0] {
+			t.Errorf("ArrayDeque PopFront should be %v but is %v", expected[0], v)
+		}
+		expected = expected[1:]
+		if v, _ := d.PopBack(); v != expected[len(expected)-1] {
+			t.Errorf("ArrayDeque PopBack should be %v but is %v", expected[len(expected)-1], v)
+		}
+		expected = expected[:len(expected)-1]
+	}
+	for i := 21; i <= 40; i++ {
+		if i%3 == 0 {
+			d.PushFront(i)
+			expected = append([]int{i}, expected...)
+		} else {
+			d.PushBack(i)
+			expected = append(expected, i)
+		}
+	}
+	if d.Size() != len(expected) {
+		t.Errorf("ArrayDeque should have size %v but has size %v", len(expected), d.Size())
+	}
+
+	// empty it from alternate ends and check the order
+	for !d.Empty() {
+		v, err := d.PopFront()
+		if err != nil || v != expected[0] {
+			t.Fatalf("ArrayDeque PopFront should be %v but is %v, %v", expected[0], v, err)
+		}
+		expected = expected[1:]
+		if d.Empty() {
+			break
+		}
+		v, err = d.PopBack()
+		if err != nil || v != expected[len(expected)-1] {
+			t.Fatalf("ArrayDeque PopBack should be %v but is %v, %v", expected[len(expected)-1], v, err)
+		}
+		expected = expected[:len(expected)-1]
+	}
+	if len(expected) != 0 {
+		t.Errorf("ArrayDeque lost elements %v", expected)
+	}
+
+	// a cleared deque still works
+	d.PushBack(1)
+	d.Clear()
+	if !d.Empty() || d.Size() != 0 {
+		t.Error("ArrayDeque should be empty after Clear")
+	}
+	d.PushFront(2)
+	d.PushBack(3)
+	if v, _ := d.PopFront(); v != 2 {
+		t.Errorf("ArrayDeque PopFront after Clear should be 2 but is %v", v)
+	}
+}
+
+// The deque behaves as a stack from either end, across many resizes.
+func TestArrayDequeAsStack(t *testing.T) {
+	var d ArrayDeque
+	for i := 0; i < 1000; i++ {
+		d.PushFront(i)
+	}
+	for i := 999; 0 <= i; i-- {
+		if v, err := d.PopFront(); err != nil || v != i {
+			t.Fatalf("ArrayDeque PopFront should be %v but is %v, %v", i, v, err)
+		}
+	}
+	for i := 0; i < 1000; i++ {
+		d.PushBack(i)
+	}
+	for i := 999; 0 <= i; i-- {
+		if v, err := d.PopBack(); err != nil || v != i {
+			t.Fatalf("ArrayDeque PopBack should be %v but is %v, %v", i, v, err)
+		}
+	}
+}
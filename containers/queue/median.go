@@ -0,0 +1,111 @@
+// median.go -- implements OnlineMedian, which tracks the median of a stream
+// of ints using two heaps:
+// - lower is a max-heap holding the smaller half of the values
+// - upper is a min-heap holding the larger half of the values
+// Invariant: lower.Size() == upper.Size() or lower.Size() == upper.Size()+1,
+// and every value in lower is <= every value in upper.
+
+// author: C. Fox
+// version: 1/2016
+
+package queue
+
+import (
+	"errors"
+)
+
+// OnlineMedian ---------------------------------------------------------------
+
+// OnlineMedian reports the median of the values added to it so far.
+type OnlineMedian struct {
+	lower intHeap // the smaller half, with values negated to make a max-heap
+	upper intHeap // the larger half
+}
+
+// Size returns the number of values added.
+func (m *OnlineMedian) Size() int { return m.lower.size() + m.upper.size() }
+
+// Empty returns true iff no values have been added.
+func (m *OnlineMedian) Empty() bool { return m.Size() == 0 }
+
+// Clear forgets all values added.
+func (m *OnlineMedian) Clear() {
+	m.lower, m.upper = nil, nil
+}
+
+// Add puts a new value x into the stream in O(lg n) time.
+func (m *OnlineMedian) Add(x int) {
+	if m.lower.size() == 0 || x <= -m.lower.top() {
+		m.lower.push(-x)
+	} else {
+		m.upper.push(x)
+	}
+	if m.upper.size()+1 < m.lower.size() {
+		m.upper.push(-m.lower.pop())
+	} else if m.lower.size() < m.upper.size() {
+		m.lower.push(-m.upper.pop())
+	}
+}
+
+// Median returns the median of the values added so far: the middle value
+// if there are an odd number of them, and otherwise the mean of the two
+// middle values.
+// Precondition: at least one value has been added.
+// Precondition violation: return 0 and an error.
+// Normal return: return the median and nil.
+func (m *OnlineMedian) Median() (float64, error) {
+	if m.Empty() {
+		return 0, errors.New("Median: no values have been added")
+	}
+	if m.upper.size() < m.lower.size() {
+		return float64(-m.lower.top()), nil
+	}
+	return (float64(-m.lower.top()) + float64(m.upper.top())) / 2, nil
+}
+
+// intHeap ---------------------------------------------------------------------
+// intHeap is a binary min-heap of ints stored in a slice.
+
+type intHeap []int
+
+// size returns the number of values in the heap.
+func (h intHeap) size() int { return len(h) }
+
+// top returns the smallest value in the heap.
+// Precondition: the heap is not empty.
+func (h intHeap) top() int { return h[0] }
+
+// push adds x to the heap and restores the heap property.
+func (h *intHeap) push(x int) {
+	*h = append(*h, x)
+	a := *h
+	for i := len(a) - 1; 0 < i && a[i] < a[(i-1)/2]; i = (i - 1) / 2 {
+		a[i], a[(i-1)/2] = a[(i-1)/2], a[i]
+	}
+}
+
+// pop removes and returns the smallest value in the heap.
+// Precondition: the heap is not empty.
+func (h *intHeap) pop() int {
+	a := *h
+	result := a[0]
+	last := len(a) - 1
+	a[0] = a[last]
+	a = a[:last]
+	for i := 0; ; {
+		smallest := i
+		if left := 2*i + 1; left < last && a[left] < a[smallest] {
+			smallest = left
+		}
+		if right := 2*i + 2; right < last && a[right] < a[smallest] {
+			smallest = right
+		}
+		if smallest == i {
+			break
+		}
+		a[i], a[smallest] = a[smallest], a[i]
+		i = smallest
+	}
+	*h = a
+	return result
+}
@@ -0,0 +1,55 @@
+// Test the OnlineMedian data structure.
+// author: C. Fox
+// version: 1/2016
+
+package queue
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestOnlineMedian(t *testing.T) {
+	var m OnlineMedian
+	if !m.Empty() || m.Size() != 0 {
+		t.Error("OnlineMedian should be empty when new")
+	}
+	if _, err := m.Median(); err == nil {
+		t.Error("OnlineMedian should not have a median before any values are added")
+	}
+
+	// a fixed sequence with hand-computed medians
+	stream := []int{5, 15, 1, 3, 2, 8, 7, 9, 10, 6, 11, 4}
+	expected := []float64{5, 10, 5, 4, 3, 4, 5, 6, 7, 6.5, 7, 6.5}
+	for i, x := range stream {
+		m.Add(x)
+		if v, err := m.Median(); err != nil || v != expected[i] {
+			t.Errorf("OnlineMedian after adding %v should be %v but is %v", stream[:i+1], expected[i], v)
+		}
+	}
+	if m.Size() != len(stream) {
+		t.Errorf("OnlineMedian size should be %v but is %v", len(stream), m.Size())
+	}
+	m.Clear()
+	if _, err := m.Median(); err == nil || !m.Empty() {
+		t.Error("OnlineMedian should have no median after Clear")
+	}
+
+	// a random sequence with duplicates checked by brute force
+	var sorted []int
+	for i := 0; i < 500; i++ {
+		x := rand.Intn(100) - 50
+		m.Add(x)
+		sorted = append(sorted, x)
+		sort.Ints(sorted)
+		n := len(sorted)
+		oracle := float64(sorted[n/2])
+		if n%2 == 0 {
+			oracle = (float64(sorted[n/2-1]) + float64(sorted[n/2])) / 2
+		}
+		if v, _ := m.Median(); v != oracle {
+			t.Fatalf("OnlineMedian after %v values should be %v but is %v", n, oracle, v)
+		}
+	}
+}
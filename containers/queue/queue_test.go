@@ -45,7 +45,7 @@ func testQueue(t *testing.T, q Queue) {
 	for i := 1; i <= 10; i++ {
 		if v, err := q.Front(); err == nil {
 			if v != i {
-				t.Errorf("Queue Front error: value", v, "should be", i)
+				t.Errorf("Queue Front error: value %v should be %v", v, i)
 			}
 		} else {
 			t.Error("Queue error: Front operation failure when queue should not be empty")
@@ -71,3 +71,164 @@ func testQueue(t *testing.T, q Queue) {
 		t.Error("Queue should be empty and size should be 0 after Clear is called")
 	}
 }
+
+func TestApply(t *testing.T) {
+	for _, q := range []Queue{new(ArrayQueue), new(LinkedQueue)} {
+		count := 0
+		q.Apply(func(e interface{}) { count++ })
+		if count != 0 {
+			t.Errorf("%T Apply should visit nothing when empty but visits %v elements", q, count)
+		}
+
+		// move the front along so that an ArrayQueue wraps around its store
+		for i := 0; i < 3; i++ {
+			q.Enter(i)
+		}
+		for i := 0; i < 3; i++ {
+			q.Leave()
+		}
+		for i := 1; i <= 5; i++ {
+			q.Enter(i)
+		}
+		var visited []interface{}
+		q.Apply(func(e interface{}) { visited = append(visited, e) })
+		if fmt.Sprint(visited) != "[1 2 3 4 5]" {
+			t.Errorf("%T Apply should visit [1 2 3 4 5] but visits %v", q, visited)
+		}
+
+		// the queue is unchanged
+		if q.Size() != 5 {
+			t.Errorf("%T should have size 5 after Apply but has %v", q, q.Size())
+		}
+		for i := 1; i <= 5; i++ {
+			if v, err := q.Leave(); err != nil || v != i {
+				t.Errorf("%T Leave after Apply should return %v but returns %v, %v", q, i, v, err)
+			}
+		}
+	}
+
+	// an ArrayQueue whose front reaches the end of its store still works
+	q := new(ArrayQueue)
+	for i := 0; i < 4; i++ {
+		q.Enter(i)
+	}
+	for i := 0; i < 4; i++ {
+		q.Leave()
+	}
+	q.Enter(9)
+	if v, err := q.Front(); err != nil || v != 9 {
+		t.Errorf("ArrayQueue Front after wrapping should return 9 but returns %v, %v", v, err)
+	}
+}
+
+func TestNewIterator(t *testing.T) {
+	for _, q := range []Queue{new(ArrayQueue), new(LinkedQueue)} {
+		iter := q.NewIterator()
+		if !iter.Done() {
+			t.Errorf("%T iterator should be done when the queue is empty", q)
+		}
+		if v, ok := iter.Next(); ok {
+			t.Errorf("%T iterator should have no next element when empty but has %v", q, v)
+		}
+
+		// move the front along so that an ArrayQueue wraps around its store
+		for i := 0; i < 3; i++ {
+			q.Enter(i)
+			q.Leave()
+		}
+		for i := 1; i <= 5; i++ {
+			q.Enter(i)
+		}
+		iter = q.NewIterator()
+		for pass := 0; pass < 2; pass++ {
+			var visited []interface{}
+			for v, ok := iter.Next(); ok; v, ok = iter.Next() {
+				visited = append(visited, v)
+			}
+			if fmt.Sprint(visited) != "[1 2 3 4 5]" {
+				t.Errorf("%T iterator should give [1 2 3 4 5] but gives %v", q, visited)
+			}
+			if !iter.Done() {
+				t.Errorf("%T iterator should be done after the last element", q)
+			}
+			iter.Reset()
+		}
+
+		// the queue is intact
+		if q.Size() != 5 {
+			t.Errorf("%T should have size 5 after iteration but has %v", q, q.Size())
+		}
+		for i := 1; i <= 5; i++ {
+			if v, err := q.Leave(); err != nil || v != i {
+				t.Errorf("%T Leave after iteration should return %v but returns %v, %v", q, i, v, err)
+			}
+		}
+	}
+}
+
+func TestDrainTo(t *testing.T) {
+	queues := []func() Queue{func() Queue { return new(ArrayQueue) }, func() Queue { return new(LinkedQueue) }}
+	for _, newSrc := range queues {
+		for _, newDst := range queues {
+			src, dst := newSrc(), newDst()
+			src.DrainTo(dst)
+			if !src.Empty() || !dst.Empty() {
+				t.Errorf("Draining empty %T into empty %T should leave both empty", src, dst)
+			}
+			dst.Enter(0)
+			for i := 1; i <= 6; i++ {
+				src.Enter(i)
+			}
+			src.Leave() // so an ArrayQueue front is not at index 0
+			src.Enter(7)
+			src.DrainTo(dst)
+			if !src.Empty() || src.Size() != 0 {
+				t.Errorf("%T should be empty after DrainTo but has size %v", src, src.Size())
+			}
+			if dst.Size() != 7 {
+				t.Errorf("%T should have size 7 after DrainTo but has %v", dst, dst.Size())
+			}
+			for i := 0; i < 8; i++ {
+				if i == 1 {
+					continue // left before draining
+				}
+				if v, err := dst.Leave(); err != nil || v != i {
+					t.Errorf("%T drained from %T should give %v but gives %v, %v", dst, src, i, v, err)
+				}
+			}
+			src.Enter(8)
+			if v, err := src.Front(); err != nil || v != 8 {
+				t.Errorf("%T should work after DrainTo but Front gives %v, %v", src, v, err)
+			}
+			src.DrainTo(src)
+			if src.Size() != 1 {
+				t.Errorf("%T drained into itself should be unchanged but has size %v", src, src.Size())
+			}
+		}
+	}
+}
+
+func TestTrimToSize(t *testing.T) {
+	q := new(ArrayQueue)
+	for i := 0; i < 1000; i++ {
+		q.Enter(i)
+	}
+	for q.Size() > 10 {
+		q.Leave()
+	}
+	q.TrimToSize()
+	if cap(q.store) != q.Size() {
+		t.Errorf("TrimToSize should leave capacity %v but leaves %v", q.Size(), cap(q.store))
+	}
+	q.Enter(1000)
+	for i := 990; i <= 1000; i++ {
+		if v, err := q.Leave(); err != nil || v != i {
+			t.Errorf("after TrimToSize Leave should return %v but returns %v, %v", i, v, err)
+		}
+	}
+	q.TrimToSize()
+	q.Enter(1)
+	if v, err := q.Front(); err != nil || v != 1 {
+		t.Errorf("Enter after trimming an empty queue failed: front is %v, %v", v, err)
+	}
+}
@@ -23,10 +23,13 @@ import (
 
 // Queue is the interface for queues in the container hierarchy.
 type Queue interface {
-	containers.Container         // include Size, Clear, and Empty
-	Front() (interface{}, error) // return the front element of a non-empty queue
-	Leave() (interface{}, error) // remove and return the front element of a non-empty queue
-	Enter(e interface{})         // place a new element on at the rear of the queue
+	containers.Container              // include Size, Clear, and Empty
+	Front() (interface{}, error)      // return the front element of a non-empty queue
+	Leave() (interface{}, error)      // remove and return the front element of a non-empty queue
+	Enter(e interface{})              // place a new element on at the rear of the queue
+	Apply(f func(interface{}))        // call f on every element from front to rear
+	NewIterator() containers.Iterator // return an iterator from front to rear
+	DrainTo(dst Queue)                // move every element, front to rear, into dst
 }
 
 // ArrayQueue -----------------------------------------------------------------------
@@ -73,7 +76,7 @@ func (q *ArrayQueue) Leave() (interface{}, error) {
 		return nil, errors.New("Leave: the queue cannot be empty")
 	}
 	result := q.store[q.frontIndex]
-	q.frontIndex++
+	q.frontIndex = (q.frontIndex + 1) % len(q.store)
 	q.count--
 	return result, nil
 }
@@ -90,6 +93,68 @@ func (q *ArrayQueue) Enter(e interface{}) {
 	q.count++
 }
 
+// TrimToSize reallocates the queue's storage to hold exactly its current
+// elements, freeing any excess capacity. Since the next Enter must then
+// reallocate again, this is only worth doing when the queue has shrunk a lot
+// and is not expected to grow back soon.
+// The elements are moved so that the front element is at index 0.
+func (q *ArrayQueue) TrimToSize() {
+	store := make([]interface{}, q.count)
+	for i := range store {
+		store[i] = q.store[(q.frontIndex+i)%len(q.store)]
+	}
+	q.store, q.frontIndex = store, 0
+}
+
+// Apply calls f on every element from the front of the queue to the rear.
+func (q *ArrayQueue) Apply(f func(interface{})) {
+	for i := 0; i < q.count; i++ {
+		f(q.store[(q.frontIndex+i)%len(q.store)])
+	}
+}
+
+// DrainTo removes every element from the queue and enters it into dst, in
+// order from front to rear, leaving the queue empty. Draining a queue into
+// itself leaves it unchanged.
+func (q *ArrayQueue) DrainTo(dst Queue) {
+	if dst == Queue(q) {
+		return
+	}
+	q.Apply(dst.Enter)
+	q.Clear()
+}
+
+// arrayQueueIterator is the data structure for an ArrayQueue external iterator.
+type arrayQueueIterator struct {
+	queue *ArrayQueue // the queue that is iterated over
+	next  int         // how many elements from the front the next one is
+}
+
+// Reset prepares an iterator to traverse its associated queue.
+func (iter *arrayQueueIterator) Reset() { iter.next = 0 }
+
+// Done is true iff the iterator has traversed its associated queue.
+func (iter *arrayQueueIterator) Done() bool { return iter.queue.count <= iter.next }
+
+// Next returns the next element and an indication of whether iteration is complete.
+// Precondition: Iteration is not complete.
+// Precondition violation: return nil and false.
+// Normal return: the next element in the iteration and true.
+func (iter *arrayQueueIterator) Next() (interface{}, bool) {
+	q := iter.queue
+	if q.count <= iter.next {
+		return nil, false
+	}
+	iter.next++
+	return q.store[(q.frontIndex+iter.next-1)%len(q.store)], true
+}
+
+// NewIterator creates and returns an iterator that goes from the front of
+// the queue to the rear without changing the queue.
+func (q *ArrayQueue) NewIterator() containers.Iterator {
+	return &arrayQueueIterator{q, 0}
+}
+
 // String makes a report on the container.
 func (q *ArrayQueue) String() string {
 	return fmt.Sprintf("ArrayQueue instance:\nsize: %d\nfrontIndex: %d\nstore len: %d\nstore cap: %d\n"+
@@ -164,6 +229,55 @@ func (q *LinkedQueue) Enter(e interface{}) {
 	q.count++
 }
 
+// Apply calls f on every element from the front of the queue to the rear.
+func (q *LinkedQueue) Apply(f func(interface{})) {
+	for n := q.frontPtr; n != nil; n = n.next {
+		f(n.item)
+	}
+}
+
+// DrainTo removes every element from the queue and enters it into dst, in
+// order from front to rear, leaving the queue empty. Draining a queue into
+// itself leaves it unchanged.
+func (q *LinkedQueue) DrainTo(dst Queue) {
+	if dst == Queue(q) {
+		return
+	}
+	q.Apply(dst.Enter)
+	q.Clear()
+}
+
+// linkedQueueIterator is the data structure for a LinkedQueue external iterator.
+type linkedQueueIterator struct {
+	queue   *LinkedQueue // the queue that is iterated over
+	current *node        // the node holding the element that is next
+}
+
+// Reset prepares an iterator to traverse its associated queue.
+func (iter *linkedQueueIterator) Reset() { iter.current = iter.queue.frontPtr }
+
+// Done is true iff the iterator has traversed its associated queue.
+func (iter *linkedQueueIterator) Done() bool { return iter.current == nil }
+
+// Next returns the next element and an indication of whether iteration is complete.
+// Precondition: Iteration is not complete.
+// Precondition violation: return nil and false.
+// Normal return: the next element in the iteration and true.
+func (iter *linkedQueueIterator) Next() (interface{}, bool) {
+	if iter.current == nil {
+		return nil, false
+	}
+	result := iter.current.item
+	iter.current = iter.current.next
+	return result, true
+}
+
+// NewIterator creates and returns an iterator that goes from the front of
+// the queue to the rear without changing the queue.
+func (q *LinkedQueue) NewIterator() containers.Iterator {
+	return &linkedQueueIterator{q, q.frontPtr}
+}
+
 // String makes a report on the container.
 func (q *LinkedQueue) String() string {
 	var result = fmt.Sprintf("LinkedQueue instance:\nsize: %d\ncontents:", q.count)
@@ -0,0 +1,136 @@
+// ringBuffer.go -- implementation of the RingBuffer part of the containers/queue package
+// author: C. Fox
+// version: 1/2016
+//
+// A RingBuffer holds the most recent elements added to it, up to a fixed
+// capacity: once it is full, each new element overwrites the oldest one.
+package queue
+
+import (
+	"containers"
+	"fmt"
+)
+
+// RingBuffer -----------------------------------------------------------------
+// A slice of length capacity is used as a circular buffer. The oldest element
+// is at store[oldestIndex], and the newest is at
+// store[(oldestIndex+count-1)%len(store)]. When the buffer is full, a new
+// element replaces the oldest one, and the next element becomes the oldest.
+// Invariant: 0 <= count <= len(store)
+
+// RingBuffer is a fixed-capacity collection that overwrites its oldest element
+// when a new one is added while it is full.
+type RingBuffer struct {
+	count       int           // how many elements are in the buffer
+	oldestIndex int           // store[oldestIndex] is the oldest element
+	store       []interface{} // circular buffer for the elements
+}
+
+// NewRingBuffer returns an empty RingBuffer that holds at most capacity elements.
+// Precondition: capacity > 0.
+// Precondition violation: panic.
+func NewRingBuffer(capacity int) *RingBuffer {
+	if capacity <= 0 {
+		panic(fmt.Sprintf("NewRingBuffer: capacity must be positive: %d", capacity))
+	}
+	return &RingBuffer{store: make([]interface{}, capacity)}
+}
+
+// Size returns the number of elements in the buffer.
+func (b *RingBuffer) Size() int { return b.count }
+
+// Capacity returns the most elements the buffer may hold.
+func (b *RingBuffer) Capacity() int { return len(b.store) }
+
+// Clear makes the buffer empty.
+func (b *RingBuffer) Clear() {
+	for i := range b.store {
+		b.store[i] = nil
+	}
+	b.count, b.oldestIndex = 0, 0
+}
+
+// Empty returns true iff the buffer is empty.
+func (b *RingBuffer) Empty() bool { return b.count == 0 }
+
+// Full returns true iff the buffer is full, so that Add overwrites an element.
+func (b *RingBuffer) Full() bool { return b.count == len(b.store) }
+
+// Add puts e into the buffer as its newest element. If the buffer is full,
+// e replaces the oldest element.
+func (b *RingBuffer) Add(e interface{}) {
+	if b.Full() {
+		b.store[b.oldestIndex] = e
+		b.oldestIndex = (b.oldestIndex + 1) % len(b.store)
+		return
+	}
+	b.store[(b.oldestIndex+b.count)%len(b.store)] = e
+	b.count++
+}
+
+// Get returns the element at index i, counting from the oldest (at 0) to the
+// newest (at Size()-1).
+// Precondition: 0 <= i < Size().
+// Precondition violation: return nil and an error indication.
+// Normal return: the element at i and nil.
+func (b *RingBuffer) Get(i int) (interface{}, error) {
+	if i < 0 || b.count <= i {
+		return nil, fmt.Errorf("Get: index out of bounds: %d", i)
+	}
+	return b.store[(b.oldestIndex+i)%len(b.store)], nil
+}
+
+// Contains returns true iff element e is in the buffer, comparing elements
+// with containers.ElementsEqual so that uncomparable ones do not cause a panic.
+func (b *RingBuffer) Contains(e interface{}) bool {
+	for i := 0; i < b.count; i++ {
+		if containers.ElementsEqual(b.store[(b.oldestIndex+i)%len(b.store)], e) {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply calls function f on every element in the buffer, from oldest to newest.
+func (b *RingBuffer) Apply(f func(interface{})) {
+	for i := 0; i < b.count; i++ {
+		f(b.store[(b.oldestIndex+i)%len(b.store)])
+	}
+}
+
+// ringBufferIterator is the data structure for a RingBuffer external iterator.
+type ringBufferIterator struct {
+	buffer *RingBuffer // the buffer that is iterated over
+	next   int         // how many elements from the oldest the next one is
+}
+
+// Reset prepares an iterator to traverse its associated buffer.
+func (iter *ringBufferIterator) Reset() { iter.next = 0 }
+
+// Done is true iff the iterator has traversed its associated buffer.
+func (iter *ringBufferIterator) Done() bool { return iter.buffer.count <= iter.next }
+
+// Next returns the next element and an indication of whether iteration is complete.
+// Precondition: Iteration is not complete.
+// Precondition violation: return nil and false.
+// Normal return: the next element in the iteration and true.
+func (iter *ringBufferIterator) Next() (interface{}, bool) {
+	result, err := iter.buffer.Get(iter.next)
+	if err != nil {
+		return nil, false
+	}
+	iter.next++
+	return result, true
+}
+
+// NewIterator creates and returns an iterator that goes from the oldest
+// element of the buffer to the newest.
+func (b *RingBuffer) NewIterator() containers.Iterator {
+	return &ringBufferIterator{b, 0}
+}
+
+// String makes a report on the container.
+func (b *RingBuffer) String() string {
+	return fmt.Sprintf("RingBuffer instance:\nsize: %d\noldestIndex: %d\ncapacity: %d\n"+
+		"store: %v\n", b.count, b.oldestIndex, len(b.store), b.store)
+}
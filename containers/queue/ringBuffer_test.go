@@ -0,0 +1,115 @@
+// Test the RingBuffer data structure.
+// author: C. Fox
+// version: 1/2016
+
+package queue
+
+import (
+	"fmt"
+	"testing"
+
+	"containers"
+)
+
+func TestRingBuffer(t *testing.T) {
+	b := NewRingBuffer(4)
+	if !b.Empty() || b.Size() != 0 || b.Full() || b.Capacity() != 4 {
+		t.Error("RingBuffer should be empty with capacity 4 when new")
+	}
+	if v, err := b.Get(0); err == nil {
+		t.Errorf("RingBuffer Get should fail when empty but returns %v", v)
+	}
+	for iter := b.NewIterator(); !iter.Done(); iter.Next() {
+		t.Error("Empty RingBuffer should not do iteration")
+	}
+
+	// add 1..10; only 7..10 survive, oldest first
+	for i := 1; i <= 10; i++ {
+		b.Add(i)
+		expected := i
+		if 4 < expected {
+			expected = 4
+		}
+		if b.Size() != expected {
+			t.Errorf("RingBuffer should have size %v after %v adds but has %v", expected, i, b.Size())
+		}
+	}
+	if !b.Full() || b.Size() != 4 {
+		t.Errorf("RingBuffer should be full with size 4 but has size %v", b.Size())
+	}
+	for i := 0; i < 4; i++ {
+		if v, err := b.Get(i); err != nil || v != 7+i {
+			t.Errorf("RingBuffer Get(%v) should be %v but is %v, %v", i, 7+i, v, err)
+		}
+	}
+	for _, i := range []int{-1, 4} {
+		if v, err := b.Get(i); err == nil {
+			t.Errorf("RingBuffer Get(%v) should fail but returns %v", i, v)
+		}
+	}
+	if !b.Contains(7) || !b.Contains(10) || b.Contains(6) {
+		t.Error("RingBuffer Contains is wrong after overwriting")
+	}
+	expected := 7
+	iter := b.NewIterator()
+	for v, ok := iter.Next(); ok; v, ok = iter.Next() {
+		if v != expected {
+			t.Errorf("RingBuffer iterator should give %v but gives %v", expected, v)
+		}
+		expected++
+	}
+	if expected != 11 {
+		t.Errorf("RingBuffer iterator gave %v elements instead of 4", expected-7)
+	}
+	iter.Reset()
+	if v, ok := iter.Next(); !ok || v != 7 {
+		t.Errorf("Reset RingBuffer iterator should give 7 but gives %v", v)
+	}
+	sum := 0
+	b.Apply(func(e interface{}) { sum += e.(int) })
+	if sum != 7+8+9+10 {
+		t.Errorf("RingBuffer Apply should sum to 34 but sums to %v", sum)
+	}
+
+	b.Clear()
+	if !b.Empty() || b.Full() || b.Contains(7) {
+		t.Error("RingBuffer should be empty after Clear")
+	}
+	b.Add("a")
+	if v, err := b.Get(0); err != nil || v != "a" {
+		t.Errorf("RingBuffer Get(0) after Clear should be a but is %v, %v", v, err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("NewRingBuffer should panic on capacity 0")
+		}
+	}()
+	NewRingBuffer(0)
+}
+
+func TestRingBufferToSlice(t *testing.T) {
+	b := NewRingBuffer(3)
+	if s := containers.ToSlice(b); len(s) != 0 {
+		t.Errorf("ToSlice of an empty RingBuffer should be empty but is %v", s)
+	}
+	for i := 1; i <= 5; i++ {
+		b.Add(i)
+	}
+	if s := containers.ToSlice(b); fmt.Sprint(s) != "[3 4 5]" {
+		t.Errorf("ToSlice of a RingBuffer should be [3 4 5] but is %v", s)
+	}
+}
+
+func TestRingBufferContainsUncomparable(t *testing.T) {
+	b := NewRingBuffer(2)
+	for _, e := range [][]int{{1}, {2, 3}, {4, 5}} {
+		b.Add(e)
+	}
+	if !b.Contains([]int{2, 3}) || !b.Contains([]int{4, 5}) {
+		t.Error("RingBuffer should contain the []ints added last")
+	}
+	if b.Contains([]int{1}) || b.Contains(7) {
+		t.Error("RingBuffer should not contain an overwritten []int or a missing value")
+	}
+}
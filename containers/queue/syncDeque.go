@@ -0,0 +1,174 @@
+// syncDeque.go -- implementation of the SyncDeque part of the containers/queue package
+// author: C. Fox
+// version: 1/2016
+//
+// A SyncDeque is a bounded deque that may be shared by many goroutines. The
+// Wait operations block until they can proceed; the others fail at once.
+package queue
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// SyncDeque ------------------------------------------------------------------
+// An ArrayDeque holds the elements, and mutex guards every access to it.
+// Goroutines waiting to add elements wait on notFull, and goroutines waiting
+// to remove elements wait on notEmpty; both conditions use mutex.
+// Invariant: deque.Size() <= capacity
+
+// SyncDeque is a bounded, blocking deque safe for concurrent use.
+type SyncDeque struct {
+	mutex    sync.Mutex
+	notEmpty *sync.Cond // signalled when an element is added
+	notFull  *sync.Cond // signalled when an element is removed
+	deque    ArrayDeque // the elements
+	capacity int        // the most elements the deque may hold
+}
+
+// NewSyncDeque returns an empty SyncDeque that holds at most capacity elements.
+// Precondition: capacity > 0.
+// Precondition violation: panic.
+func NewSyncDeque(capacity int) *SyncDeque {
+	if capacity <= 0 {
+		panic(fmt.Sprintf("NewSyncDeque: capacity must be positive: %d", capacity))
+	}
+	result := &SyncDeque{capacity: capacity}
+	result.notEmpty = sync.NewCond(&result.mutex)
+	result.notFull = sync.NewCond(&result.mutex)
+	return result
+}
+
+// Size returns the number of elements in the deque.
+func (d *SyncDeque) Size() int {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.deque.Size()
+}
+
+// Capacity returns the most elements the deque may hold.
+func (d *SyncDeque) Capacity() int { return d.capacity }
+
+// Clear makes the deque empty, releasing any goroutines waiting to add elements.
+func (d *SyncDeque) Clear() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.deque.Clear()
+	d.notFull.Broadcast()
+}
+
+// Empty returns true iff the deque is empty.
+func (d *SyncDeque) Empty() bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.deque.Empty()
+}
+
+// PushFront adds a new element at the front of the deque.
+// Precondition: the deque is not full.
+// Precondition violation: add nothing and return an error indication.
+// Normal return: nil.
+func (d *SyncDeque) PushFront(e interface{}) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.deque.Size() == d.capacity {
+		return errors.New("PushFront: the deque is full")
+	}
+	d.deque.PushFront(e)
+	d.notEmpty.Signal()
+	return nil
+}
+
+// PushBack adds a new element at the rear of the deque.
+// Precondition: the deque is not full.
+// Precondition violation: add nothing and return an error indication.
+// Normal return: nil.
+func (d *SyncDeque) PushBack(e interface{}) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.deque.Size() == d.capacity {
+		return errors.New("PushBack: the deque is full")
+	}
+	d.deque.PushBack(e)
+	d.notEmpty.Signal()
+	return nil
+}
+
+// PopFront removes and returns the front element of the deque.
+// Precondition: the deque is not empty.
+// Precondition violation: return nil and an error indication.
+// Normal return: the front element and nil.
+func (d *SyncDeque) PopFront() (interface{}, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	result, err := d.deque.PopFront()
+	if err == nil {
+		d.notFull.Signal()
+	}
+	return result, err
+}
+
+// PopBack removes and returns the rear element of the deque.
+// Precondition: the deque is not empty.
+// Precondition violation: return nil and an error indication.
+// Normal return: the rear element and nil.
+func (d *SyncDeque) PopBack() (interface{}, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	result, err := d.deque.PopBack()
+	if err == nil {
+		d.notFull.Signal()
+	}
+	return result, err
+}
+
+// PushFrontWait adds a new element at the front of the deque, first waiting
+// until the deque is not full.
+func (d *SyncDeque) PushFrontWait(e interface{}) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	for d.deque.Size() == d.capacity {
+		d.notFull.Wait()
+	}
+	d.deque.PushFront(e)
+	d.notEmpty.Signal()
+}
+
+// PushBackWait adds a new element at the rear of the deque, first waiting
+// until the deque is not full.
+func (d *SyncDeque) PushBackWait(e interface{}) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	for d.deque.Size() == d.capacity {
+		d.notFull.Wait()
+	}
+	d.deque.PushBack(e)
+	d.notEmpty.Signal()
+}
+
+// PopFrontWait removes and returns the front element of the deque, first
+// waiting until the deque is not empty.
+func (d *SyncDeque) PopFrontWait() interface{} {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	for d.deque.Empty() {
+		d.notEmpty.Wait()
+	}
+	result, _ := d.deque.PopFront()
+	d.notFull.Signal()
+	return result
+}
+
+// PopBackWait removes and returns the rear element of the deque, first
+// waiting until the deque is not empty.
+func (d *SyncDeque) PopBackWait() interface{} {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	for d.deque.Empty() {
+		d.notEmpty.Wait()
+	}
+	result, _ := d.deque.PopBack()
+	d.notFull.Signal()
+	return result
+}
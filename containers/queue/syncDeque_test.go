@@ -0,0 +1,151 @@
+// Test the SyncDeque data structure.
+// author: C. Fox
+// version: 1/2016
+
+package queue
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSyncDeque(t *testing.T) {
+	d := NewSyncDeque(3)
+	if !d.Empty() || d.Size() != 0 || d.Capacity() != 3 {
+		t.Errorf("SyncDeque should be empty with capacity 3 when new but has size %v and capacity %v", d.Size(), d.Capacity())
+	}
+	if v, err := d.PopFront(); err == nil {
+		t.Errorf("SyncDeque PopFront should fail when empty but returns %v", v)
+	}
+	if v, err := d.PopBack(); err == nil {
+		t.Errorf("SyncDeque PopBack should fail when empty but returns %v", v)
+	}
+
+	// fill it as 1 2 3 from both ends
+	if err := d.PushBack(2); err != nil {
+		t.Errorf("SyncDeque PushBack fails: %v", err)
+	}
+	if err := d.PushFront(1); err != nil {
+		t.Errorf("SyncDeque PushFront fails: %v", err)
+	}
+	if err := d.PushBack(3); err != nil {
+		t.Errorf("SyncDeque PushBack fails: %v", err)
+	}
+	if err := d.PushBack(4); err == nil {
+		t.Error("SyncDeque PushBack should fail when full")
+	}
+	if err := d.PushFront(0); err == nil {
+		t.Error("SyncDeque PushFront should fail when full")
+	}
+	if d.Size() != 3 {
+		t.Errorf("SyncDeque should have size 3 but has size %v", d.Size())
+	}
+	if v, err := d.PopBack(); err != nil || v != 3 {
+		t.Errorf("SyncDeque PopBack should return 3 but returns %v, %v", v, err)
+	}
+	if v := d.PopFrontWait(); v != 1 {
+		t.Errorf("SyncDeque PopFrontWait should return 1 but returns %v", v)
+	}
+	if v := d.PopBackWait(); v != 2 {
+		t.Errorf("SyncDeque PopBackWait should return 2 but returns %v", v)
+	}
+	d.PushFrontWait(5)
+	d.Clear()
+	if !d.Empty() {
+		t.Error("SyncDeque should be empty after Clear")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("NewSyncDeque should panic on capacity 0")
+		}
+	}()
+	NewSyncDeque(0)
+}
+
+func TestSyncDequeBlocking(t *testing.T) {
+	d := NewSyncDeque(1)
+
+	// a pop on an empty deque waits for a push
+	result := make(chan interface{})
+	go func() { result <- d.PopBackWait() }()
+	select {
+	case v := <-result:
+		t.Errorf("SyncDeque PopBackWait should block when empty but returns %v", v)
+	case <-time.After(10 * time.Millisecond):
+	}
+	d.PushBackWait(7)
+	if v := <-result; v != 7 {
+		t.Errorf("SyncDeque PopBackWait should return 7 but returns %v", v)
+	}
+
+	// a push on a full deque waits for a pop
+	d.PushBackWait(8)
+	done := make(chan bool)
+	go func() { d.PushBackWait(9); done <- true }()
+	select {
+	case <-done:
+		t.Error("SyncDeque PushBackWait should block when full")
+	case <-time.After(10 * time.Millisecond):
+	}
+	if v := d.PopFrontWait(); v != 8 {
+		t.Errorf("SyncDeque PopFrontWait should return 8 but returns %v", v)
+	}
+	<-done
+	if v := d.PopFrontWait(); v != 9 {
+		t.Errorf("SyncDeque PopFrontWait should return 9 but returns %v", v)
+	}
+}
+
+// Producers push distinct ints at the back while consumers take them from
+// both ends; every int must come out exactly once.
+func TestSyncDequeConcurrent(t *testing.T) {
+	const producers, consumers, perProducer = 4, 6, 600
+	d := NewSyncDeque(8)
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				if i%2 == 0 {
+					d.PushBackWait(p*perProducer + i)
+				} else {
+					d.PushFrontWait(p*perProducer + i)
+				}
+			}
+		}(p)
+	}
+	taken := make(chan int, producers*perProducer)
+	for c := 0; c < consumers; c++ {
+		wg.Add(1)
+		go func(c int) {
+			defer wg.Done()
+			for i := 0; i < producers*perProducer/consumers; i++ {
+				if (c+i)%2 == 0 {
+					taken <- d.PopFrontWait().(int)
+				} else {
+					taken <- d.PopBackWait().(int)
+				}
+			}
+		}(c)
+	}
+	wg.Wait()
+	close(taken)
+	seen := make([]bool, producers*perProducer)
+	for v := range taken {
+		if seen[v] {
+			t.Errorf("SyncDeque returned %v more than once", v)
+		}
+		seen[v] = true
+	}
+	for v, ok := range seen {
+		if !ok {
+			t.Errorf("SyncDeque lost %v", v)
+		}
+	}
+	if !d.Empty() {
+		t.Errorf("SyncDeque should be empty at the end but has size %v", d.Size())
+	}
+}
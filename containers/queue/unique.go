@@ -0,0 +1,52 @@
+// unique.go -- implements FirstUnique, which tracks the oldest element of a
+// stream that has appeared exactly once so far. Elements that may be unique
+// wait in a queue in order of arrival, and a hash map records how many times
+// each element has appeared. Elements at the front of the queue that have
+// appeared more than once are discarded lazily when First is called, so the
+// amortized cost of both operations is O(1).
+
+// author: C. Fox
+// version: 1/2016
+
+package queue
+
+import (
+	"containers/dictionary"
+)
+
+// FirstUnique ----------------------------------------------------------------
+
+// FirstUnique reports the first element in a stream that is not repeated.
+type FirstUnique struct {
+	candidates LinkedQueue        // elements seen once, in order of arrival
+	counts     dictionary.HashMap // how many times each element has appeared
+}
+
+// Add puts element e at the end of the stream.
+// Precondition: e implements the containers.Hasher interface.
+// Precondition violation: panic.
+func (f *FirstUnique) Add(e interface{}) {
+	count := 0
+	if c, ok := f.counts.Get(e); ok {
+		count = c.(int)
+	}
+	f.counts.Insert(e, count+1)
+	if count == 0 {
+		f.candidates.Enter(e)
+	}
+}
+
+// First returns the oldest element of the stream that has appeared exactly once.
+// Precondition: some element in the stream has appeared exactly once.
+// Precondition violation: return nil and false.
+// Normal return: return the oldest unique element and true.
+func (f *FirstUnique) First() (interface{}, bool) {
+	for !f.candidates.Empty() {
+		e, _ := f.candidates.Front()
+		if c, _ := f.counts.Get(e); c == 1 {
+			return e, true
+		}
+		f.candidates.Leave()
+	}
+	return nil, false
+}
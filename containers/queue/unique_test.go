@@ -0,0 +1,37 @@
+// Test the FirstUnique data structure.
+// author: C. Fox
+// version: 1/2016
+
+package queue
+
+import (
+	"testing"
+)
+
+// Letter is a Hasher type for stream elements
+type Letter byte
+
+func (c Letter) Equal(d interface{}) bool { return c == d.(Letter) }
+func (c Letter) Hash(tableSize int) int   { return int(c) % tableSize }
+
+func TestFirstUnique(t *testing.T) {
+	var f FirstUnique
+	if e, ok := f.First(); ok {
+		t.Errorf("FirstUnique should have no first element when new but has %v", e)
+	}
+
+	// a '-' means there is no unique element
+	stream := "abacbdcedxex"
+	expected := "aabbccddeex-"
+	for i := range stream {
+		f.Add(Letter(stream[i]))
+		e, ok := f.First()
+		if expected[i] == '-' {
+			if ok {
+				t.Errorf("FirstUnique after %q should have no first element but has %c", stream[:i+1], e)
+			}
+		} else if !ok || e != Letter(expected[i]) {
+			t.Errorf("FirstUnique after %q should be %c but is %v", stream[:i+1], expected[i], e)
+		}
+	}
+}
@@ -245,3 +245,291 @@ func testSet(t *testing.T, set Set, name string) {
 		t.Error(name + "should be empty and size should be 0 after clear is called")
 	}
 }
+
+func TestIntersectionSize(t *testing.T) {
+	sets := []Set{new(TreeSet), new(HashSet), new(TreeSet), new(HashSet)}
+	for _, k := range []int{2, 3, 5, 10, 20} {
+		sets[0].Insert(KeyValue{k, ""})
+		sets[1].Insert(KeyValue{k, ""})
+	}
+	for _, k := range []int{2, 4, 5, 6, 7, 12, 20} {
+		sets[2].Insert(KeyValue{k, ""})
+	}
+	for _, k := range []int{1, 11, 21} {
+		sets[3].Insert(KeyValue{k, ""})
+	}
+	empty := new(HashSet)
+	expected := [][]int{{5, 5, 3, 0}, {5, 5, 3, 0}, {3, 3, 7, 0}, {0, 0, 0, 3}}
+	for i, s := range sets {
+		if n := s.IntersectionSize(empty); n != 0 {
+			t.Errorf("Intersection size of set %v and the empty set should be 0 but is %v", i, n)
+		}
+		for j, u := range sets {
+			n := s.IntersectionSize(u)
+			if n != expected[i][j] {
+				t.Errorf("Intersection size of sets %v and %v should be %v but is %v", i, j, expected[i][j], n)
+			}
+			if m := s.Intersection(u).Size(); n != m {
+				t.Errorf("Intersection size of sets %v and %v is %v but the intersection has size %v", i, j, n, m)
+			}
+		}
+	}
+}
+
+func TestRandomIterator(t *testing.T) {
+	for _, set := range []Set{new(TreeSet), new(HashSet)} {
+		if iter := set.NewRandomIterator(1); !iter.Done() {
+			t.Errorf("%T random iterator over an empty set should be done", set)
+		}
+		for i := 0; i < 100; i++ {
+			set.Insert(KeyValue{i, fmt.Sprint(i)})
+		}
+
+		// every element is visited once
+		var order []interface{}
+		visits := make(map[int]int)
+		iter := set.NewRandomIterator(42)
+		for e, ok := iter.Next(); ok; e, ok = iter.Next() {
+			order = append(order, e)
+			visits[e.(KeyValue).key]++
+		}
+		if len(order) != set.Size() || len(visits) != set.Size() {
+			t.Errorf("%T random iterator should visit %v elements but visited %v", set, set.Size(), len(visits))
+		}
+		inOrder := true
+		for i, e := range order {
+			inOrder = inOrder && e.(KeyValue).key == i
+		}
+		if inOrder {
+			t.Errorf("%T random iterator should not produce sorted order", set)
+		}
+
+		// the same seed gives the same order, including after Reset
+		again := set.NewRandomIterator(42)
+		for _, e := range order {
+			if f, _ := again.Next(); e != f {
+				t.Errorf("%T random iterators with the same seed should agree", set)
+				break
+			}
+		}
+		iter.Reset()
+		if e, _ := iter.Next(); e != order[0] {
+			t.Errorf("%T random iterator should repeat its order after Reset", set)
+		}
+		different := false
+		other := set.NewRandomIterator(7)
+		for _, e := range order {
+			f, _ := other.Next()
+			different = different || e != f
+		}
+		if !different {
+			t.Errorf("%T random iterators with different seeds should not agree", set)
+		}
+	}
+}
+
+func TestFilterAndMap(t *testing.T) {
+	for _, set := range []Set{new(TreeSet), new(HashSet)} {
+		isOdd := func(e interface{}) bool { return e.(KeyValue).key%2 == 1 }
+		half := func(e interface{}) interface{} {
+			kv := e.(KeyValue)
+			return KeyValue{kv.key / 2, kv.value + "/2"}
+		}
+		if r := set.Filter(isOdd); !r.Empty() {
+			t.Errorf("Filter of an empty %T should be empty but has size %v", set, r.Size())
+		}
+		if r := set.Map(half); !r.Empty() {
+			t.Errorf("Map of an empty %T should be empty but has size %v", set, r.Size())
+		}
+		for k := 0; k < 10; k++ {
+			set.Insert(KeyValue{k, fmt.Sprint(k)})
+		}
+
+		odd := set.Filter(isOdd)
+		if fmt.Sprintf("%T", odd) != fmt.Sprintf("%T", set) {
+			t.Errorf("Filter of a %T should be a %T but is a %T", set, set, odd)
+		}
+		if odd.Size() != 5 {
+			t.Errorf("Filter of a %T should have size 5 but has size %v", set, odd.Size())
+		}
+		for k := 0; k < 10; k++ {
+			if odd.Contains(KeyValue{k, ""}) != (k%2 == 1) {
+				t.Errorf("Filter of a %T is wrong about whether it contains %v", set, k)
+			}
+		}
+
+		halves := set.Map(half)
+		if fmt.Sprintf("%T", halves) != fmt.Sprintf("%T", set) {
+			t.Errorf("Map of a %T should be a %T but is a %T", set, set, halves)
+		}
+		if halves.Size() != 5 {
+			t.Errorf("Map of a %T should have collided down to size 5 but has size %v", set, halves.Size())
+		}
+		for k := 0; k < 5; k++ {
+			if !halves.Contains(KeyValue{k, ""}) {
+				t.Errorf("Map of a %T should contain %v", set, k)
+			}
+		}
+
+		// the receiver is unchanged
+		if set.Size() != 10 {
+			t.Errorf("%T should still have size 10 after Filter and Map but has %v", set, set.Size())
+		}
+	}
+}
+
+func TestPowerSet(t *testing.T) {
+	for _, set := range []Set{new(TreeSet), new(HashSet)} {
+		subsets := PowerSet(set)
+		if len(subsets) != 1 || !subsets[0].Empty() {
+			t.Errorf("PowerSet of an empty %T should hold just the empty set but is %v", set, subsets)
+		}
+		for k := 1; k <= 3; k++ {
+			set.Insert(KeyValue{k, ""})
+		}
+		subsets = PowerSet(set)
+		if len(subsets) != 8 {
+			t.Fatalf("PowerSet of a 3-element %T should have 8 subsets but has %v", set, len(subsets))
+		}
+
+		// each subset is a subset of the right type, and no two are equal
+		seen := map[string]bool{}
+		for _, sub := range subsets {
+			if fmt.Sprintf("%T", sub) != fmt.Sprintf("%T", set) {
+				t.Errorf("PowerSet of a %T should hold %Ts but holds a %T", set, set, sub)
+			}
+			if !sub.Subset(set) {
+				t.Errorf("PowerSet of a %T holds a non-subset of size %v", set, sub.Size())
+			}
+			members := ""
+			for k := 1; k <= 3; k++ {
+				if sub.Contains(KeyValue{k, ""}) {
+					members += fmt.Sprint(k)
+				}
+			}
+			if len(members) != sub.Size() {
+				t.Errorf("PowerSet of a %T holds a subset with unexpected members", set)
+			}
+			if seen[members] {
+				t.Errorf("PowerSet of a %T holds subset {%v} twice", set, members)
+			}
+			seen[members] = true
+		}
+		if !seen[""] || !seen["123"] {
+			t.Errorf("PowerSet of a %T should hold the empty and full sets", set)
+		}
+		if !subsets[0].Empty() || !subsets[7].Equal(set) {
+			t.Errorf("PowerSet of a %T should start with the empty set and end with the full set", set)
+		}
+
+		// changing a subset does not change the set
+		subsets[7].Delete(KeyValue{1, ""})
+		if set.Size() != 3 {
+			t.Errorf("%T should be unchanged by changing a subset but has size %v", set, set.Size())
+		}
+	}
+
+	// huge sets are refused
+	set := new(HashSet)
+	for k := 0; k <= MaxPowerSetSize; k++ {
+		set.Insert(KeyValue{k, ""})
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("PowerSet should panic on a set with %v elements", set.Size())
+		}
+	}()
+	PowerSet(set)
+}
+
+func TestCount(t *testing.T) {
+	set := new(TreeSet)
+	for k := 0; k < 10; k++ {
+		set.Insert(KeyValue{k, fmt.Sprint(k)})
+	}
+	isSmall := func(e interface{}) bool { return e.(KeyValue).key < 3 }
+	if count := containers.Count(set, isSmall); count != 3 {
+		t.Errorf("Count of small keys in a TreeSet should be 3 but is %v", count)
+	}
+	if !containers.Any(set, isSmall) || containers.All(set, isSmall) {
+		t.Error("TreeSet should have some but not all small keys")
+	}
+	if !containers.All(set, func(e interface{}) bool { return e.(KeyValue).key < 10 }) {
+		t.Error("TreeSet keys should all be under 10")
+	}
+}
+
+func TestToSlice(t *testing.T) {
+	for _, set := range []Set{new(TreeSet), new(HashSet)} {
+		for _, k := range []int{5, 2, 8} {
+			set.Insert(KeyValue{k, fmt.Sprint(k)})
+		}
+		s := containers.ToSlice(set)
+		if len(s) != 3 {
+			t.Fatalf("ToSlice of a %T should have 3 elements but is %v", set, s)
+		}
+		for _, e := range s {
+			if !set.Contains(e) {
+				t.Errorf("ToSlice of a %T has %v, which is not in the set", set, e)
+			}
+		}
+	}
+	set := new(TreeSet)
+	for _, k := range []int{5, 2, 8} {
+		set.Insert(KeyValue{k, ""})
+	}
+	if s := containers.ToSlice(set); s[0].(KeyValue).key != 2 || s[2].(KeyValue).key != 8 {
+		t.Errorf("ToSlice of a TreeSet should be in order but is %v", s)
+	}
+}
+
+func TestEqualUnordered(t *testing.T) {
+	ts, hs := new(TreeSet), new(HashSet)
+	if !containers.EqualUnordered(ts, hs) {
+		t.Error("Empty TreeSet and HashSet should be EqualUnordered")
+	}
+	for _, k := range []int{17, 3, 250, 42, 8, 99} {
+		ts.Insert(KeyValue{k, ""})
+		hs.Insert(KeyValue{k, ""})
+	}
+	if !containers.EqualUnordered(ts, hs) || !containers.EqualUnordered(hs, ts) {
+		t.Error("TreeSet and HashSet with the same elements should be EqualUnordered")
+	}
+	hs.Delete(KeyValue{42, ""})
+	hs.Insert(KeyValue{43, ""})
+	if containers.EqualUnordered(ts, hs) || containers.EqualUnordered(hs, ts) {
+		t.Error("TreeSet and HashSet with different elements should not be EqualUnordered")
+	}
+	hs.Delete(KeyValue{43, ""})
+	if containers.EqualUnordered(ts, hs) {
+		t.Error("TreeSet and HashSet of different sizes should not be EqualUnordered")
+	}
+}
+
+func TestReadyMadeKeys(t *testing.T) {
+	tree := new(TreeSet)
+	for _, k := range []int{5, -3, 8, 0, 5} {
+		tree.Insert(containers.IntKey(k))
+	}
+	if s := containers.ToSlice(tree); fmt.Sprint(s) != "[-3 0 5 8]" {
+		t.Errorf("TreeSet of IntKeys should be [-3 0 5 8] but is %v", s)
+	}
+	if !tree.Contains(containers.IntKey(-3)) || tree.Contains(containers.IntKey(3)) {
+		t.Error("TreeSet of IntKeys should contain only the keys inserted")
+	}
+
+	hash := new(HashSet)
+	for _, s := range []string{"pear", "plum", "fig", "plum"} {
+		hash.Insert(containers.StringKey(s))
+	}
+	if hash.Size() != 3 {
+		t.Errorf("HashSet of StringKeys should have size 3 but has %v", hash.Size())
+	}
+	if !hash.Contains(containers.StringKey("fig")) || hash.Contains(containers.StringKey("kiwi")) {
+		t.Error("HashSet of StringKeys should contain only the keys inserted")
+	}
+	hash.Delete(containers.StringKey("plum"))
+	if hash.Contains(containers.StringKey("plum")) || hash.Size() != 2 {
+		t.Error("HashSet of StringKeys should not contain plum after Delete")
+	}
+}
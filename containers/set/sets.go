@@ -11,6 +11,9 @@
 package set
 
 import (
+	"fmt"
+	"math/rand"
+
 	"containers"
 	"containers/internal/hashtbl"
 	"containers/internal/tree"
@@ -18,14 +21,18 @@ import (
 
 // Set is the interface for sets in the containers hierarchy.
 type Set interface {
-	containers.Collection     // Size, Clear, Empty, Contains, NewIterator, Apply
-	Subset(set Set) bool      // Say whether the receiver is contained in another set
-	Insert(e interface{})     // Put e into a set--replace the value if it is already there
-	Delete(e interface{})     // Remove e from a set--do nothing it is not there
-	Intersection(set Set) Set // Create the intersection of the receiver and set
-	Union(set Set) Set        // Create the union of the receiver and set
-	Complement(set Set) Set   // Create the relative complemenh of the receiver and set
-	Equal(set Set) bool       // true iff set is identical to the receiver
+	containers.Collection                             // Size, Clear, Empty, Contains, NewIterator, Apply
+	Subset(set Set) bool                              // Say whether the receiver is contained in another set
+	Insert(e interface{})                             // Put e into a set--replace the value if it is already there
+	Delete(e interface{})                             // Remove e from a set--do nothing it is not there
+	Intersection(set Set) Set                         // Create the intersection of the receiver and set
+	IntersectionSize(set Set) int                     // Count the elements in the receiver and set
+	Union(set Set) Set                                // Create the union of the receiver and set
+	Complement(set Set) Set                           // Create the relative complemenh of the receiver and set
+	Equal(set Set) bool                               // true iff set is identical to the receiver
+	NewRandomIterator(seed int64) containers.Iterator // Iterate over the elements in a shuffled order
+	Filter(pred func(interface{}) bool) Set           // Create a set of the elements satisfying pred
+	Map(f func(interface{}) interface{}) Set          // Create a set of f applied to each element
 }
 
 // TreeSet ////////////////////////////////////////////////////////////
@@ -56,6 +63,12 @@ func (s *TreeSet) NewIterator() containers.Iterator {
 	return s.tree.NewInorderIterator()
 }
 
+// NewRandomIterator returns an iterator over the elements of the set in an
+// order shuffled by a random number generator seeded with seed.
+func (s *TreeSet) NewRandomIterator(seed int64) containers.Iterator {
+	return newRandomIterator(s, seed)
+}
+
 // Apply invokes function f on every value in the set.
 func (s *TreeSet) Apply(f func(interface{})) { s.tree.VisitInorder(f) }
 
@@ -102,6 +115,10 @@ func (s *TreeSet) Intersection(set Set) Set {
 	return result
 }
 
+// IntersectionSize returns the size of the intersection of the receiver
+// and set without building it.
+func (s *TreeSet) IntersectionSize(set Set) int { return intersectionSize(s, set) }
+
 // Union returns the union of the receiver and set.
 func (s *TreeSet) Union(set Set) Set {
 	result := new(TreeSet)
@@ -128,6 +145,17 @@ func (s *TreeSet) Complement(set Set) Set {
 	return result
 }
 
+// Filter returns a new TreeSet holding the elements for which pred is true.
+func (s *TreeSet) Filter(pred func(interface{}) bool) Set {
+	return filterInto(new(TreeSet), s, pred)
+}
+
+// Map returns a new TreeSet holding f applied to each element. It may be
+// smaller than the receiver if f maps different elements to equal ones.
+func (s *TreeSet) Map(f func(interface{}) interface{}) Set {
+	return mapInto(new(TreeSet), s, f)
+}
+
 // HashSet ////////////////////////////////////////////////////////////
 // HashSet is the data structure for a hash-table-based implementation
 // of sets that uses values that implement the Hasher interface.
@@ -155,6 +183,12 @@ func (s *HashSet) Contains(e interface{}) bool {
 // NewIterator creates and returns a new external iterator value.
 func (s *HashSet) NewIterator() containers.Iterator { return s.table.NewIterator() }
 
+// NewRandomIterator returns an iterator over the elements of the set in an
+// order shuffled by a random number generator seeded with seed.
+func (s *HashSet) NewRandomIterator(seed int64) containers.Iterator {
+	return newRandomIterator(s, seed)
+}
+
 // Apply invokes function f on every value in the set.
 func (s *HashSet) Apply(f func(interface{})) {
 	iter := s.NewIterator()
@@ -206,6 +240,10 @@ func (s *HashSet) Intersection(set Set) Set {
 	return result
 }
 
+// IntersectionSize returns the size of the intersection of the receiver
+// and set without building it.
+func (s *HashSet) IntersectionSize(set Set) int { return intersectionSize(s, set) }
+
 // Union returns the union of the receiver and set.
 func (s *HashSet) Union(set Set) Set {
 	result := new(HashSet)
@@ -231,3 +269,122 @@ func (s *HashSet) Complement(set Set) Set {
 	}
 	return result
 }
+
+// Filter returns a new HashSet holding the elements for which pred is true.
+func (s *HashSet) Filter(pred func(interface{}) bool) Set {
+	return filterInto(new(HashSet), s, pred)
+}
+
+// Map returns a new HashSet holding f applied to each element. It may be
+// smaller than the receiver if f maps different elements to equal ones.
+func (s *HashSet) Map(f func(interface{}) interface{}) Set {
+	return mapInto(new(HashSet), s, f)
+}
+
+// Package functions //////////////////////////////////////////////////
+
+// MaxPowerSetSize is the largest set PowerSet will accept.
+const MaxPowerSetSize = 20
+
+// PowerSet returns every subset of s, each a new set of the same type as s.
+// There are 2^n subsets of a set with n elements, so this is only practical
+// for small sets. Subset i holds the elements whose positions in the order
+// s.NewIterator produces them correspond to the 1 bits of i, so the first
+// subset is empty and the last is a copy of s.
+// Precondition: s.Size() <= MaxPowerSetSize.
+// Precondition violation: panic.
+func PowerSet(s Set) []Set {
+	if MaxPowerSetSize < s.Size() {
+		panic(fmt.Sprintf("PowerSet: the set has %d elements; the most allowed is %d", s.Size(), MaxPowerSetSize))
+	}
+	var elements []interface{}
+	s.Apply(func(e interface{}) { elements = append(elements, e) })
+	none := func(interface{}) bool { return false } // filtering with none makes an empty set of s's type
+	result := make([]Set, 1<<uint(len(elements)))
+	for i := range result {
+		result[i] = s.Filter(none)
+		for j, e := range elements {
+			if i&(1<<uint(j)) != 0 {
+				result[i].Insert(e)
+			}
+		}
+	}
+	return result
+}
+
+// randomIterator ///////////////////////////////////////////////////
+// A randomIterator traverses a shuffled copy of the elements of a set made
+// when the iterator is created, so changes to the set afterwards are not
+// seen. Reset starts over with the same order.
+
+// randomIterator is the data structure for a set random-order iterator.
+type randomIterator struct {
+	elements []interface{} // the shuffled elements
+	next     int           // which element is next
+}
+
+// newRandomIterator copies the elements of s into a slice and shuffles it.
+func newRandomIterator(s Set, seed int64) *randomIterator {
+	result := new(randomIterator)
+	result.elements = make([]interface{}, 0, s.Size())
+	s.Apply(func(e interface{}) { result.elements = append(result.elements, e) })
+	r := rand.New(rand.NewSource(seed))
+	for i := len(result.elements) - 1; 0 < i; i-- {
+		j := r.Intn(i + 1)
+		result.elements[i], result.elements[j] = result.elements[j], result.elements[i]
+	}
+	return result
+}
+
+// Reset prepares an iterator to traverse its shuffled elements again.
+func (iter *randomIterator) Reset() { iter.next = 0 }
+
+// Done is true iff the iterator has produced every element.
+func (iter *randomIterator) Done() bool { return len(iter.elements) <= iter.next }
+
+// Next returns a value and an indication of whether iteration is complete.
+// Precondition: Iteration is not complete.
+// Precondition violation: return nil and false.
+// Normal return: the next element in the iteration and true.
+func (iter *randomIterator) Next() (interface{}, bool) {
+	if iter.Done() {
+		return nil, false
+	}
+	iter.next++
+	return iter.elements[iter.next-1], true
+}
+
+// Helper functions ///////////////////////////////////////////////////
+
+// intersectionSize counts the elements common to s and t by iterating over
+// the smaller set and checking whether each element is in the larger one.
+func intersectionSize(s, t Set) int {
+	if t.Size() < s.Size() {
+		s, t = t, s
+	}
+	result := 0
+	iter := s.NewIterator()
+	for e, ok := iter.Next(); ok; e, ok = iter.Next() {
+		if t.Contains(e) {
+			result++
+		}
+	}
+	return result
+}
+
+// filterInto inserts the elements of s for which pred is true into result,
+// and returns result.
+func filterInto(result, s Set, pred func(interface{}) bool) Set {
+	s.Apply(func(e interface{}) {
+		if pred(e) {
+			result.Insert(e)
+		}
+	})
+	return result
+}
+
+// mapInto inserts f applied to each element of s into result, and returns result.
+func mapInto(result, s Set, f func(interface{}) interface{}) Set {
+	s.Apply(func(e interface{}) { result.Insert(f(e)) })
+	return result
+}
@@ -76,3 +76,148 @@ func testStack(t *testing.T, s Stack) {
 		t.Error("Stack should be empty and size should be 0 after clear is called")
 	}
 }
+
+func TestApply(t *testing.T) {
+	for _, s := range []Stack{new(ArrayStack), new(LinkedStack)} {
+		count := 0
+		s.Apply(func(e interface{}) { count++ })
+		if count != 0 {
+			t.Errorf("%T Apply should visit nothing when empty but visits %v elements", s, count)
+		}
+		for i := 1; i <= 5; i++ {
+			s.Push(i)
+		}
+		var visited []interface{}
+		s.Apply(func(e interface{}) { visited = append(visited, e) })
+		if fmt.Sprint(visited) != "[5 4 3 2 1]" {
+			t.Errorf("%T Apply should visit [5 4 3 2 1] but visits %v", s, visited)
+		}
+
+		// the stack is unchanged
+		if s.Size() != 5 {
+			t.Errorf("%T should have size 5 after Apply but has %v", s, s.Size())
+		}
+		for i := 5; 1 <= i; i-- {
+			if v, err := s.Pop(); err != nil || v != i {
+				t.Errorf("%T Pop after Apply should return %v but returns %v, %v", s, i, v, err)
+			}
+		}
+	}
+}
+
+func TestNewIterator(t *testing.T) {
+	for _, s := range []Stack{new(ArrayStack), new(LinkedStack)} {
+		iter := s.NewIterator()
+		if !iter.Done() {
+			t.Errorf("%T iterator should be done when the stack is empty", s)
+		}
+		if v, ok := iter.Next(); ok {
+			t.Errorf("%T iterator should have no next element when empty but has %v", s, v)
+		}
+		for i := 1; i <= 5; i++ {
+			s.Push(i)
+		}
+		iter = s.NewIterator()
+		for pass := 0; pass < 2; pass++ {
+			var visited []interface{}
+			for !iter.Done() {
+				v, _ := iter.Next()
+				visited = append(visited, v)
+			}
+			if fmt.Sprint(visited) != "[5 4 3 2 1]" {
+				t.Errorf("%T iterator should give [5 4 3 2 1] but gives %v", s, visited)
+			}
+			if v, ok := iter.Next(); ok {
+				t.Errorf("%T iterator should have no next element when done but has %v", s, v)
+			}
+			iter.Reset()
+		}
+
+		// the stack is intact
+		if s.Size() != 5 {
+			t.Errorf("%T should have size 5 after iteration but has %v", s, s.Size())
+		}
+		for i := 5; 1 <= i; i-- {
+			if v, err := s.Pop(); err != nil || v != i {
+				t.Errorf("%T Pop after iteration should return %v but returns %v, %v", s, i, v, err)
+			}
+		}
+	}
+}
+
+func TestPeek(t *testing.T) {
+	for _, s := range []Stack{new(ArrayStack), new(LinkedStack)} {
+		if v, err := s.Peek(0); err == nil {
+			t.Errorf("%T Peek(0) should fail when empty but returns %v", s, v)
+		}
+		for i := 1; i <= 5; i++ {
+			s.Push(i)
+		}
+		for depth := 0; depth < 5; depth++ {
+			if v, err := s.Peek(depth); err != nil || v != 5-depth {
+				t.Errorf("%T Peek(%v) should return %v but returns %v, %v", s, depth, 5-depth, v, err)
+			}
+		}
+		for _, depth := range []int{-1, 5, 9} {
+			if v, err := s.Peek(depth); err == nil {
+				t.Errorf("%T Peek(%v) should fail but returns %v", s, depth, v)
+			}
+		}
+		if s.Size() != 5 {
+			t.Errorf("%T should have size 5 after Peek but has %v", s, s.Size())
+		}
+		if v, err := s.Top(); err != nil || v != 5 {
+			t.Errorf("%T Top after Peek should return 5 but returns %v, %v", s, v, err)
+		}
+	}
+}
+
+func TestDrainTo(t *testing.T) {
+	stacks := []func() Stack{func() Stack { return new(ArrayStack) }, func() Stack { return new(LinkedStack) }}
+	for _, newSrc := range stacks {
+		for _, newDst := range stacks {
+			src, dst := newSrc(), newDst()
+			dst.Push(0)
+			for i := 1; i <= 5; i++ {
+				src.Push(i)
+			}
+			src.DrainTo(dst)
+			if !src.Empty() {
+				t.Errorf("%T should be empty after DrainTo but has size %v", src, src.Size())
+			}
+			if dst.Size() != 6 {
+				t.Errorf("%T should have size 6 after DrainTo but has %v", dst, dst.Size())
+			}
+			for _, i := range []int{1, 2, 3, 4, 5, 0} {
+				if v, err := dst.Pop(); err != nil || v != i {
+					t.Errorf("%T drained from %T should pop %v but pops %v, %v", dst, src, i, v, err)
+				}
+			}
+			src.Push(6)
+			src.DrainTo(src)
+			if v, err := src.Top(); err != nil || v != 6 || src.Size() != 1 {
+				t.Errorf("%T drained into itself should be unchanged but has top %v, %v", src, v, err)
+			}
+		}
+	}
+}
+
+func TestTrimToSize(t *testing.T) {
+	s := new(ArrayStack)
+	for i := 0; i < 1000; i++ {
+		s.Push(i)
+	}
+	for s.Size() > 10 {
+		s.Pop()
+	}
+	s.TrimToSize()
+	if cap(s.store) != s.Size() {
+		t.Errorf("TrimToSize should leave capacity %v but leaves %v", s.Size(), cap(s.store))
+	}
+	s.Push(10)
+	for i := 10; 0 <= i; i-- {
+		if v, err := s.Pop(); err != nil || v != i {
+			t.Errorf("after TrimToSize Pop should return %v but returns %v, %v", i, v, err)
+		}
+	}
+}
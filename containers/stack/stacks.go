@@ -17,10 +17,14 @@ import (
 
 // Stack is the interface for stacks in the containers hierarchy.
 type Stack interface {
-	containers.Container       // include Size, Clear, and Empty
-	Push(e interface{})        // place a new element on the top of the stack
-	Pop() (interface{}, error) // remove and return top element of a non-empty stack
-	Top() (interface{}, error) // return the top element of a non-empty stack
+	containers.Container                 // include Size, Clear, and Empty
+	Push(e interface{})                  // place a new element on the top of the stack
+	Pop() (interface{}, error)           // remove and return top element of a non-empty stack
+	Top() (interface{}, error)           // return the top element of a non-empty stack
+	Peek(depth int) (interface{}, error) // return the element depth below the top; pre: 0 <= depth < Size()
+	Apply(f func(interface{}))           // call f on every element from top to bottom
+	NewIterator() containers.Iterator    // return an iterator from top to bottom
+	DrainTo(dst Stack)                   // pop every element and push it onto dst
 }
 
 // ArrayStack ----------------------------------------------------------------
@@ -58,6 +62,16 @@ func (s *ArrayStack) Pop() (interface{}, error) {
 	return result, nil
 }
 
+// TrimToSize reallocates the stack's storage to hold exactly its current
+// elements, freeing any excess capacity. Since the next Push must then
+// reallocate again, this is only worth doing when the stack has shrunk a lot
+// and is not expected to grow back soon.
+func (s *ArrayStack) TrimToSize() {
+	store := make([]interface{}, len(s.store))
+	copy(store, s.store)
+	s.store = store
+}
+
 // Top returns the top value on the stack without removing it.
 // Pre: the stack is not empty.
 // Pre violation: return nil and an error indication.
@@ -69,6 +83,69 @@ func (s *ArrayStack) Top() (interface{}, error) {
 	return s.store[len(s.store)-1], nil
 }
 
+// Peek returns the element depth places below the top of the stack without
+// removing it, so Peek(0) is the top element.
+// Precondition: 0 <= depth < Size().
+// Precondition violation: return nil and an error indication.
+// Normal return: return the element (which is not removed) and nil.
+func (s *ArrayStack) Peek(depth int) (interface{}, error) {
+	if depth < 0 || len(s.store) <= depth {
+		return nil, fmt.Errorf("Peek: depth out of bounds: %d", depth)
+	}
+	return s.store[len(s.store)-1-depth], nil
+}
+
+// Apply calls f on every element from the top of the stack to the bottom.
+func (s *ArrayStack) Apply(f func(interface{})) {
+	for i := len(s.store) - 1; 0 <= i; i-- {
+		f(s.store[i])
+	}
+}
+
+// DrainTo pops every element from the stack and pushes it onto dst, leaving
+// the stack empty. The elements end up on dst in the reverse order, with the
+// bottom element of the stack on top. Draining a stack into itself leaves it
+// unchanged.
+func (s *ArrayStack) DrainTo(dst Stack) {
+	if dst == Stack(s) {
+		return
+	}
+	s.Apply(dst.Push)
+	s.Clear()
+}
+
+// arrayStackIterator is the data structure for an ArrayStack external iterator.
+type arrayStackIterator struct {
+	stack *ArrayStack // the stack that is iterated over
+	next  int         // index of the element that is next
+}
+
+// Reset prepares an iterator to traverse its associated stack.
+func (iter *arrayStackIterator) Reset() { iter.next = len(iter.stack.store) - 1 }
+
+// Done is true iff the iterator has traversed its associated stack.
+func (iter *arrayStackIterator) Done() bool { return iter.next < 0 }
+
+// Next returns the next element and an indication of whether iteration is complete.
+// Precondition: Iteration is not complete.
+// Precondition violation: return nil and false.
+// Normal return: the next element in the iteration and true.
+func (iter *arrayStackIterator) Next() (interface{}, bool) {
+	if iter.next < 0 {
+		return nil, false
+	}
+	iter.next--
+	return iter.stack.store[iter.next+1], true
+}
+
+// NewIterator creates and returns an iterator that goes from the top of the
+// stack to the bottom without changing the stack.
+func (s *ArrayStack) NewIterator() containers.Iterator {
+	result := &arrayStackIterator{stack: s}
+	result.Reset()
+	return result
+}
+
 // String makes a report on the container.
 func (s *ArrayStack) String() string {
 	return fmt.Sprintf("ArrayStack instance:\nstore len: %d\nstore cap: %d\nstore: %v\n",
@@ -133,7 +210,72 @@ func (s *LinkedStack) Top() (interface{}, error) {
 	return s.topPtr.item, nil
 }
 
-// Pop removes and returns the top element on the stack.
+// Peek returns the element depth places below the top of the stack without
+// removing it, so Peek(0) is the top element.
+// Precondition: 0 <= depth < Size().
+// Precondition violation: return nil and an error indication.
+// Normal return: return the element (which is not removed) and nil.
+func (s *LinkedStack) Peek(depth int) (interface{}, error) {
+	if depth < 0 || s.count <= depth {
+		return nil, fmt.Errorf("Peek: depth out of bounds: %d", depth)
+	}
+	n := s.topPtr
+	for ; 0 < depth; depth-- {
+		n = n.next
+	}
+	return n.item, nil
+}
+
+// Apply calls f on every element from the top of the stack to the bottom.
+func (s *LinkedStack) Apply(f func(interface{})) {
+	for n := s.topPtr; n != nil; n = n.next {
+		f(n.item)
+	}
+}
+
+// DrainTo pops every element from the stack and pushes it onto dst, leaving
+// the stack empty. The elements end up on dst in the reverse order, with the
+// bottom element of the stack on top. Draining a stack into itself leaves it
+// unchanged.
+func (s *LinkedStack) DrainTo(dst Stack) {
+	if dst == Stack(s) {
+		return
+	}
+	s.Apply(dst.Push)
+	s.Clear()
+}
+
+// linkedStackIterator is the data structure for a LinkedStack external iterator.
+type linkedStackIterator struct {
+	stack   *LinkedStack // the stack that is iterated over
+	current *node        // the node holding the element that is next
+}
+
+// Reset prepares an iterator to traverse its associated stack.
+func (iter *linkedStackIterator) Reset() { iter.current = iter.stack.topPtr }
+
+// Done is true iff the iterator has traversed its associated stack.
+func (iter *linkedStackIterator) Done() bool { return iter.current == nil }
+
+// Next returns the next element and an indication of whether iteration is complete.
+// Precondition: Iteration is not complete.
+// Precondition violation: return nil and false.
+// Normal return: the next element in the iteration and true.
+func (iter *linkedStackIterator) Next() (interface{}, bool) {
+	if iter.current == nil {
+		return nil, false
+	}
+	result := iter.current.item
+	iter.current = iter.current.next
+	return result, true
+}
+
+// NewIterator creates and returns an iterator that goes from the top of the
+// stack to the bottom without changing the stack.
+func (s *LinkedStack) NewIterator() containers.Iterator {
+	return &linkedStackIterator{s, s.topPtr}
+}
+
 // String makes a report on the container.
 func (s *LinkedStack) String() string {
 	var result = fmt.Sprintf("LinkedStack instance:\nsize: %d\ncontents:", s.count)
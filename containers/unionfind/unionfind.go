@@ -0,0 +1,79 @@
+// unionfind.go -- implements the containers/unionfind package
+// author: C. Fox
+// version: 1/2016
+//
+// unionfind provides UnionFind, a disjoint-set forest over the ints 0..n-1.
+// Each set is a tree whose root is its representative. Find compresses the
+// path it follows so that every node on it points directly to the root, and
+// Union hangs the tree of lower rank beneath the other, so a sequence of m
+// operations takes O(m α(n)) time, where α is the inverse Ackermann function.
+package unionfind
+
+// UnionFind ------------------------------------------------------------------
+// parent[x] is the parent of x in its tree, or x itself if x is a root.
+// rank[x] is an upper bound on the height of the tree rooted at x.
+// Invariant: count is the number of roots.
+
+// UnionFind is a collection of disjoint sets of the ints 0..n-1.
+type UnionFind struct {
+	parent []int
+	rank   []int
+	count  int
+}
+
+// NewUnionFind returns a UnionFind with each of 0..n-1 in its own set.
+// Precondition: n >= 0.
+// Precondition violation: panic.
+func NewUnionFind(n int) *UnionFind {
+	result := &UnionFind{parent: make([]int, n), rank: make([]int, n), count: n}
+	for x := range result.parent {
+		result.parent[x] = x
+	}
+	return result
+}
+
+// Size returns the number of elements in all the sets.
+func (u *UnionFind) Size() int { return len(u.parent) }
+
+// Count returns the number of disjoint sets.
+func (u *UnionFind) Count() int { return u.count }
+
+// Find returns the representative of the set containing x.
+// Precondition: 0 <= x < Size().
+// Precondition violation: panic.
+func (u *UnionFind) Find(x int) int {
+	root := x
+	for u.parent[root] != root {
+		root = u.parent[root]
+	}
+	for u.parent[x] != root {
+		x, u.parent[x] = u.parent[x], root
+	}
+	return root
+}
+
+// Union merges the sets containing x and y; nothing happens if they are
+// already in the same set.
+// Precondition: 0 <= x, y < Size().
+// Precondition violation: panic.
+func (u *UnionFind) Union(x, y int) {
+	rx, ry := u.Find(x), u.Find(y)
+	if rx == ry {
+		return
+	}
+	if u.rank[rx] < u.rank[ry] {
+		rx, ry = ry, rx
+	}
+	u.parent[ry] = rx
+	if u.rank[rx] == u.rank[ry] {
+		u.rank[rx]++
+	}
+	u.count--
+}
+
+// Connected returns true iff x and y are in the same set.
+// Precondition: 0 <= x, y < Size().
+// Precondition violation: panic.
+func (u *UnionFind) Connected(x, y int) bool {
+	return u.Find(x) == u.Find(y)
+}
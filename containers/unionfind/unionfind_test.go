@@ -0,0 +1,89 @@
+// Test the UnionFind data structure.
+// author: C. Fox
+// version: 1/2016
+
+package unionfind
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// roots returns the number of distinct representatives in u.
+func roots(u *UnionFind) int {
+	found := make(map[int]bool)
+	for x := 0; x < u.Size(); x++ {
+		found[u.Find(x)] = true
+	}
+	return len(found)
+}
+
+func TestUnionFind(t *testing.T) {
+	u := NewUnionFind(10)
+	if u.Size() != 10 || u.Count() != 10 || roots(u) != 10 {
+		t.Errorf("UnionFind should start with 10 singletons but has %v sets", u.Count())
+	}
+	for x := 0; x < 10; x++ {
+		if u.Find(x) != x {
+			t.Errorf("UnionFind singleton %v should be its own representative", x)
+		}
+	}
+
+	// build the sets {0,2,4,6,8}, {1,3,5}, {7}, {9}
+	pairs := [][2]int{{0, 2}, {4, 6}, {2, 4}, {8, 0}, {1, 3}, {5, 3}, {6, 8}, {3, 1}}
+	for _, p := range pairs {
+		u.Union(p[0], p[1])
+	}
+	if u.Count() != 4 || roots(u) != 4 {
+		t.Errorf("UnionFind should have 4 sets but has %v with %v roots", u.Count(), roots(u))
+	}
+	set := []int{0, 1, 0, 1, 0, 1, 0, 7, 0, 9}
+	for x := 0; x < 10; x++ {
+		for y := 0; y < 10; y++ {
+			if u.Connected(x, y) != (set[x] == set[y]) {
+				t.Errorf("UnionFind connection of %v and %v should be %v", x, y, set[x] == set[y])
+			}
+		}
+	}
+
+	// merging everything leaves one set
+	u.Union(9, 5)
+	u.Union(7, 4)
+	u.Union(1, 7)
+	if u.Count() != 1 || roots(u) != 1 || !u.Connected(9, 0) {
+		t.Errorf("UnionFind should have 1 set but has %v", u.Count())
+	}
+}
+
+func TestUnionFindRandom(t *testing.T) {
+	// compare against labelling each element with its component
+	n := 200
+	u := NewUnionFind(n)
+	label := make([]int, n)
+	for x := range label {
+		label[x] = x
+	}
+	components := n
+	for i := 0; i < 150; i++ {
+		x, y := rand.Intn(n), rand.Intn(n)
+		u.Union(x, y)
+		if lx, ly := label[x], label[y]; lx != ly {
+			for z := range label {
+				if label[z] == ly {
+					label[z] = lx
+				}
+			}
+			components--
+		}
+		if u.Count() != components || roots(u) != components {
+			t.Fatalf("UnionFind should have %v sets but has %v with %v roots", components, u.Count(), roots(u))
+		}
+	}
+	for x := 0; x < n; x++ {
+		for y := 0; y < n; y++ {
+			if u.Connected(x, y) != (label[x] == label[y]) {
+				t.Fatalf("UnionFind connection of %v and %v should be %v", x, y, label[x] == label[y])
+			}
+		}
+	}
+}
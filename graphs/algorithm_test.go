@@ -14,6 +14,8 @@ func TestAlgorithms(t *testing.T) {
 	testSearch(t, "LinkedGraph", NewLinkedGraph(20))
 	testAlgorithms(t, "ArrayGraph", NewArrayGraph(10))
 	testAlgorithms(t, "LinkedGraph", NewLinkedGraph(10))
+	testTopologicalSort(t, "ArrayDigraph", NewArrayDigraph(8))
+	testTopologicalSort(t, "LinkedDigraph", NewLinkedDigraph(8))
 	s := fmt.Sprintf("Be quiet about fmt imported but not used already")
 	s += "!"
 }
@@ -84,43 +86,43 @@ func testAlgorithms(t *testing.T, name string, g Graph) {
 	g.AddEdge(8, 7)
 	g.AddEdge(8, 9)
 	if IsPath(g, -1, g.Vertices()-1) {
-		t.Errorf(name + ": there is no path to a vertex outside the graph (-1)")
+		t.Error(name + ": there is no path to a vertex outside the graph (-1)")
 	}
 	if IsPath(g, 1, g.Vertices()) {
-		t.Errorf(name + ": there is no path to a vertex outside the graph (Vertices())")
+		t.Error(name + ": there is no path to a vertex outside the graph (Vertices())")
 	}
 	if IsPath(g, 3, 6) || IsPath(g, 6, 3) {
-		t.Errorf(name + ": there is no path from 3 to 6")
+		t.Error(name + ": there is no path from 3 to 6")
 	}
 	if !IsPath(g, 5, 1) && !IsPath(g, 1, 5) {
-		t.Errorf(name + ": there is a path from 5 to 1")
+		t.Error(name + ": there is a path from 5 to 1")
 	}
 	if IsConnected(g) {
-		t.Errorf(name + ": graph is not connected, but IsConnected says it is")
+		t.Error(name + ": graph is not connected, but IsConnected says it is")
 	}
 	g.AddEdge(2, 8)
 	g.AddEdge(3, 6)
 	if !IsConnected(g) {
-		t.Errorf(name + ": graph is connected, but IsConnected says it is not")
+		t.Error(name + ": graph is connected, but IsConnected says it is not")
 	}
 
 	// test shortest path generation
 	path, _ := ShortestPath(g, 0, 9)
 	if !samePath(path, []int{0, 3, 2, 8, 9}) {
-		t.Errorf(name + ": failed to find the shortes path from 0 to 9")
+		t.Error(name + ": failed to find the shortes path from 0 to 9")
 	}
 	path, _ = ShortestPath(g, 5, 7)
 	if !samePath(path, []int{5, 3, 6, 7}) {
-		t.Errorf(name + ": failed to find the shortes path from 5 to 7")
+		t.Error(name + ": failed to find the shortes path from 5 to 7")
 	}
 
 	// test spanning tree generation
 	h, err := SpanningTree(g)
 	if err != nil {
-		t.Errorf(name + ": spanning tree generation failed")
+		t.Error(name + ": spanning tree generation failed")
 	}
 	if g.Vertices() != h.Vertices() || !IsConnected(h) || h.Edges() != h.Vertices()-1 {
-		t.Errorf(name + ": spanning tree generation failed with a bad spanning tree")
+		t.Error(name + ": spanning tree generation failed with a bad spanning tree")
 	}
 
 	// test max degree calculation
@@ -134,6 +136,405 @@ func testAlgorithms(t *testing.T, name string, g Graph) {
 	}
 }
 
+func TestSearchOrders(t *testing.T) {
+	// linked graphs put new edges first in adjacency lists, so their
+	// searches take branches in the opposite order
+	data := []struct {
+		g        Graph
+		bfs, dfs []int
+	}{{NewArrayGraph(7), []int{0, 1, 3, 2, 4, 5}, []int{0, 1, 2, 3, 4, 5}},
+		{NewLinkedGraph(7), []int{0, 3, 1, 4, 2, 5}, []int{0, 3, 4, 5, 1, 2}}}
+	for _, d := range data {
+		name := fmt.Sprintf("%T", d.g)
+
+		// two branches 0-1-2 and 0-3-4-5, with 6 unreachable
+		for _, e := range [][2]int{{0, 1}, {1, 2}, {0, 3}, {3, 4}, {4, 5}} {
+			d.g.AddEdge(e[0], e[1])
+		}
+		if order := BFSOrder(d.g, 0); !samePath(order, d.bfs) {
+			t.Errorf(name+": BFS order should be %v but is %v", d.bfs, order)
+		}
+		if order := DFSOrder(d.g, 0); !samePath(order, d.dfs) {
+			t.Errorf(name+": DFS order should be %v but is %v", d.dfs, order)
+		}
+		if order := BFSOrder(d.g, 6); !samePath(order, []int{6}) {
+			t.Errorf(name+": BFS order from an isolated vertex should be [6] but is %v", order)
+		}
+		if order := DFSOrder(d.g, 6); !samePath(order, []int{6}) {
+			t.Errorf(name+": DFS order from an isolated vertex should be [6] but is %v", order)
+		}
+		if BFSOrder(d.g, 7) != nil || DFSOrder(d.g, -1) != nil {
+			t.Error(name + ": Search orders from missing vertices should be nil")
+		}
+	}
+}
+
+func TestTransitiveClosure(t *testing.T) {
+	graphs := []Graph{NewArrayGraph(30), NewLinkedGraph(30), NewArrayDigraph(30), NewLinkedDigraph(30)}
+	for _, g := range graphs {
+		name := fmt.Sprintf("%T", g)
+		for i := 0; i < 25; i++ {
+			g.AddEdge(rand.Intn(g.Vertices()), rand.Intn(g.Vertices()))
+		}
+		closure := TransitiveClosure(g)
+		if len(closure) != g.Vertices() {
+			t.Fatalf(name+": Transitive closure should have %v rows but has %v", g.Vertices(), len(closure))
+		}
+		for v := range closure {
+			for w := range closure[v] {
+				if closure[v][w] != IsPath(g, v, w) {
+					t.Errorf(name+": Transitive closure says path %v to %v is %v but IsPath disagrees", v, w, closure[v][w])
+				}
+			}
+		}
+	}
+}
+
+func TestRandomWalk(t *testing.T) {
+	for _, g := range []Graph{NewArrayGraph(8), NewLinkedDigraph(8)} {
+		name := fmt.Sprintf("%T", g)
+
+		// a cycle 0..5 with chord 0-3, and a dead end 5->6 in the digraph
+		for v := 0; v < 6; v++ {
+			g.AddEdge(v, (v+1)%6)
+		}
+		g.AddEdge(0, 3)
+		g.AddEdge(5, 6)
+		walk := RandomWalk(g, 0, 50, 17)
+		if walk[0] != 0 {
+			t.Errorf(name+": Random walk should start at 0 but starts at %v", walk[0])
+		}
+		for i := 1; i < len(walk); i++ {
+			if !g.IsEdge(walk[i-1], walk[i]) {
+				t.Errorf(name+": Random walk %v steps from %v to %v with no edge", walk, walk[i-1], walk[i])
+			}
+		}
+		if _, isDigraph := g.(Digraph); !isDigraph && len(walk) != 51 {
+			t.Errorf(name+": Random walk of 50 steps should visit 51 vertices but visits %v", len(walk))
+		}
+		if len(walk) < 51 && walk[len(walk)-1] != 6 {
+			t.Errorf(name+": Random walk %v stopped early at a vertex with neighbors", walk)
+		}
+		again := RandomWalk(g, 0, 50, 17)
+		if !samePath(walk, again) {
+			t.Errorf(name+": Random walks with the same seed should be the same but are %v and %v", walk, again)
+		}
+		if walk := RandomWalk(g, 7, 10, 1); !samePath(walk, []int{7}) {
+			t.Errorf(name+": Random walk from isolated vertex 7 should be [7] but is %v", walk)
+		}
+		if walk := RandomWalk(g, 2, 0, 1); !samePath(walk, []int{2}) {
+			t.Errorf(name+": Random walk of 0 steps should be [2] but is %v", walk)
+		}
+		if RandomWalk(g, 8, 10, 1) != nil {
+			t.Error(name + ": Random walk from a missing vertex should be nil")
+		}
+	}
+}
+
+func TestWouldCreateCycle(t *testing.T) {
+	for _, g := range []Graph{NewArrayGraph(8), NewLinkedGraph(8)} {
+		name := fmt.Sprintf("%T", g)
+
+		// a forest with components {0,1,2,3}, {4,5}, and {6}, {7}
+		for _, e := range [][2]int{{0, 1}, {1, 2}, {1, 3}, {4, 5}} {
+			g.AddEdge(e[0], e[1])
+		}
+		for _, e := range [][2]int{{0, 2}, {2, 3}, {3, 0}, {5, 4}} {
+			if !WouldCreateCycle(g, e[0], e[1]) {
+				t.Errorf(name+": Edge %v-%v within a component should create a cycle", e[0], e[1])
+			}
+		}
+		for _, e := range [][2]int{{0, 4}, {3, 5}, {5, 6}, {6, 7}} {
+			if WouldCreateCycle(g, e[0], e[1]) {
+				t.Errorf(name+": Edge %v-%v across components should not create a cycle", e[0], e[1])
+			}
+		}
+		if WouldCreateCycle(g, 0, 8) {
+			t.Error(name + ": Edge 0-8 to a missing vertex should not create a cycle")
+		}
+
+		// joining components makes edges between them cycle-forming
+		g.AddEdge(3, 4)
+		if !WouldCreateCycle(g, 0, 5) {
+			t.Error(name + ": Edge 0-5 should create a cycle once the components are joined")
+		}
+	}
+}
+
+func TestIsBipartite(t *testing.T) {
+	for _, g := range []Graph{NewArrayGraph(9), NewLinkedGraph(9)} {
+		name := fmt.Sprintf("%T", g)
+
+		// isolated vertices and an even cycle 0-1-2-3-4-5
+		if !IsBipartite(g) {
+			t.Error(name + ": A graph with no edges should be bipartite")
+		}
+		for v := 0; v < 6; v++ {
+			g.AddEdge(v, (v+1)%6)
+		}
+		if !IsBipartite(g) {
+			t.Error(name + ": An even cycle should be bipartite")
+		}
+
+		// another component that is a path, then an odd cycle 6-7-8
+		g.AddEdge(6, 7)
+		g.AddEdge(7, 8)
+		if !IsBipartite(g) {
+			t.Error(name + ": A disconnected graph with bipartite components should be bipartite")
+		}
+		g.AddEdge(8, 6)
+		if IsBipartite(g) {
+			t.Error(name + ": A graph with an odd cycle in one component should not be bipartite")
+		}
+		g.RemoveEdge(8, 6)
+		g.AddEdge(0, 3)
+		if !IsBipartite(g) {
+			t.Error(name + ": An even cycle with a chord between opposite vertices should be bipartite")
+		}
+		g.AddEdge(0, 2)
+		if IsBipartite(g) {
+			t.Error(name + ": A graph with a triangle should not be bipartite")
+		}
+	}
+}
+
+func TestGirth(t *testing.T) {
+	for _, g := range []Graph{NewArrayGraph(8), NewLinkedGraph(8)} {
+		name := fmt.Sprintf("%T", g)
+
+		// a tree has no cycles
+		for _, e := range [][2]int{{0, 1}, {0, 2}, {1, 3}, {1, 4}, {2, 5}, {5, 6}, {5, 7}} {
+			g.AddEdge(e[0], e[1])
+		}
+		if girth := Girth(g); girth != -1 {
+			t.Errorf(name+": Girth of a tree should be -1 but is %v", girth)
+		}
+
+		// each new edge closes a shorter cycle: 0-1-3-6-5-2, 0-2-5-6, then 1-3-4
+		g.AddEdge(3, 6)
+		if girth := Girth(g); girth != 6 {
+			t.Errorf(name+": Girth should be 6 but is %v", girth)
+		}
+		g.AddEdge(0, 6)
+		if girth := Girth(g); girth != 4 {
+			t.Errorf(name+": Girth of a graph with a square should be 4 but is %v", girth)
+		}
+		g.AddEdge(3, 4)
+		if girth := Girth(g); girth != 3 {
+			t.Errorf(name+": Girth of a graph with a triangle should be 3 but is %v", girth)
+		}
+	}
+}
+
+func TestDiameter(t *testing.T) {
+	const n = 6
+	for _, linked := range []bool{false, true} {
+		// a path 0-1-2-3-4-5
+		path := make([][2]int, 0, n-1)
+		for v := 0; v < n-1; v++ {
+			path = append(path, [2]int{v, v + 1})
+		}
+		g, _ := BuildGraph(n, path, linked)
+		name := fmt.Sprintf("%T", g)
+		if d := Diameter(g); d != n-1 {
+			t.Errorf(name+": Diameter of a path should be %v but is %v", n-1, d)
+		}
+		for v, expected := range []int{5, 4, 3, 3, 4, 5} {
+			if e := Eccentricity(g, v); e != expected {
+				t.Errorf(name+": Eccentricity of path vertex %v should be %v but is %v", v, expected, e)
+			}
+		}
+
+		// a star with center 0
+		g, _ = BuildGraph(n, [][2]int{{0, 1}, {0, 2}, {0, 3}, {0, 4}, {0, 5}}, linked)
+		if d := Diameter(g); d != 2 {
+			t.Errorf(name+": Diameter of a star should be 2 but is %v", d)
+		}
+		if e := Eccentricity(g, 0); e != 1 {
+			t.Errorf(name+": Eccentricity of the star center should be 1 but is %v", e)
+		}
+
+		// disconnected graphs and bad vertices
+		g.RemoveEdge(0, 5)
+		if d := Diameter(g); d != -1 {
+			t.Errorf(name+": Diameter of a disconnected graph should be -1 but is %v", d)
+		}
+		if e := Eccentricity(g, 0); e != -1 {
+			t.Errorf(name+": Eccentricity in a disconnected graph should be -1 but is %v", e)
+		}
+		if e := Eccentricity(g, n); e != -1 {
+			t.Errorf(name+": Eccentricity of a missing vertex should be -1 but is %v", e)
+		}
+		g, _ = BuildGraph(1, nil, linked)
+		if d := Diameter(g); d != 0 {
+			t.Errorf(name+": Diameter of a single vertex should be 0 but is %v", d)
+		}
+	}
+}
+
+func TestArticulationPoints(t *testing.T) {
+	for _, linked := range []bool{false, true} {
+		// two triangles 0-1-2 and 3-4-5 joined by the bridge 2-3
+		g, _ := BuildGraph(6, [][2]int{{0, 1}, {1, 2}, {2, 0}, {2, 3}, {3, 4}, {4, 5}, {5, 3}}, linked)
+		name := fmt.Sprintf("%T", g)
+		if cut := fmt.Sprint(ArticulationPoints(g)); cut != "[2 3]" {
+			t.Errorf(name+": Articulation points of the bridge graph should be [2 3] but are %v", cut)
+		}
+
+		// a cycle has none
+		g, _ = BuildGraph(5, [][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 4}, {4, 0}}, linked)
+		if cut := ArticulationPoints(g); len(cut) != 0 {
+			t.Errorf(name+": A cycle should have no articulation points but has %v", cut)
+		}
+
+		// in a star only the center is one, whether or not the search starts there;
+		// the isolated vertex 0 makes a second component
+		g, _ = BuildGraph(5, [][2]int{{4, 1}, {4, 2}, {4, 3}}, linked)
+		if cut := fmt.Sprint(ArticulationPoints(g)); cut != "[4]" {
+			t.Errorf(name+": Articulation points of a star should be [4] but are %v", cut)
+		}
+		g, _ = BuildGraph(5, [][2]int{{1, 2}, {2, 3}, {3, 4}}, linked)
+		if cut := fmt.Sprint(ArticulationPoints(g)); cut != "[2 3]" {
+			t.Errorf(name+": Articulation points of a path should be [2 3] but are %v", cut)
+		}
+	}
+}
+
+func TestGreedyColoring(t *testing.T) {
+	for _, linked := range []bool{false, true} {
+		// a square 0-1-2-3 with the diagonal 0-2, and a pendant vertex 4 on 3
+		edges := [][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 0}, {0, 2}, {3, 4}}
+		g, _ := BuildGraph(5, edges, linked)
+		name := fmt.Sprintf("%T", g)
+		coloring := GreedyColoring(g)
+		if len(coloring) != g.Vertices() {
+			t.Fatalf(name+": GreedyColoring should color %v vertices but is %v", g.Vertices(), coloring)
+		}
+		for _, e := range edges {
+			if coloring[e[0]] == coloring[e[1]] {
+				t.Errorf(name+": GreedyColoring %v gives adjacent vertices %v and %v the same color", coloring, e[0], e[1])
+			}
+		}
+		if fmt.Sprint(coloring) != "[0 1 2 1 0]" || ColorCount(coloring) != 3 {
+			t.Errorf(name+": GreedyColoring should be [0 1 2 1 0] with 3 colors but is %v with %v",
+				coloring, ColorCount(coloring))
+		}
+
+		// with no edges one color will do
+		g, _ = BuildGraph(4, nil, linked)
+		if count := ColorCount(GreedyColoring(g)); count != 1 {
+			t.Errorf(name+": GreedyColoring of an edgeless graph should use 1 color but uses %v", count)
+		}
+	}
+	if count := ColorCount(nil); count != 0 {
+		t.Errorf("ColorCount of an empty coloring should be 0 but is %v", count)
+	}
+}
+
+func TestEulerPath(t *testing.T) {
+	// isEulerPath checks that path walks along every edge of g exactly once.
+	isEulerPath := func(g Graph, path []int) bool {
+		if len(path) != g.Edges()+1 {
+			return false
+		}
+		used := make(map[[2]int]bool)
+		for i := 1; i < len(path); i++ {
+			v, w := path[i-1], path[i]
+			if w < v {
+				v, w = w, v
+			}
+			if !g.IsEdge(v, w) || used[[2]int{v, w}] {
+				return false
+			}
+			used[[2]int{v, w}] = true
+		}
+		return true
+	}
+	for _, linked := range []bool{false, true} {
+		// two triangles sharing vertex 2 (a bowtie) have an Euler circuit
+		g, _ := BuildGraph(5, [][2]int{{0, 1}, {1, 2}, {2, 0}, {2, 3}, {3, 4}, {4, 2}}, linked)
+		name := fmt.Sprintf("%T", g)
+		if !HasEulerCircuit(g) || !HasEulerPath(g) {
+			t.Error(name + ": The bowtie should have an Euler circuit and path")
+		}
+		path, err := EulerPath(g)
+		if err != nil || !isEulerPath(g, path) || path[0] != path[len(path)-1] {
+			t.Errorf(name+": EulerPath of the bowtie should be a circuit but is %v, %v", path, err)
+		}
+
+		// a house (the square 0-1-2-3 with the roof 2-4-3) has only a path, between 2 and 3
+		g, _ = BuildGraph(5, [][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 0}, {2, 4}, {4, 3}}, linked)
+		if HasEulerCircuit(g) || !HasEulerPath(g) {
+			t.Error(name + ": The house should have an Euler path but no circuit")
+		}
+		path, err = EulerPath(g)
+		if err != nil || !isEulerPath(g, path) {
+			t.Errorf(name+": EulerPath of the house is wrong: %v, %v", path, err)
+		} else if ends := [2]int{path[0], path[len(path)-1]}; ends != [2]int{2, 3} {
+			t.Errorf(name+": EulerPath of the house should go from 2 to 3 but is %v", path)
+		}
+
+		// four odd vertices, or edges in two components, rule out a path
+		g, _ = BuildGraph(4, [][2]int{{0, 1}, {0, 2}, {0, 3}}, linked)
+		if HasEulerPath(g) {
+			t.Error(name + ": A star with three leaves should not have an Euler path")
+		}
+		if path, err := EulerPath(g); err == nil {
+			t.Errorf(name+": EulerPath of a star with three leaves should fail but is %v", path)
+		}
+		g, _ = BuildGraph(6, [][2]int{{0, 1}, {1, 2}, {2, 0}, {3, 4}, {4, 5}, {5, 3}}, linked)
+		if HasEulerCircuit(g) || HasEulerPath(g) {
+			t.Error(name + ": Two separate triangles should not have an Euler path")
+		}
+
+		// isolated vertices do not matter
+		g, _ = BuildGraph(5, [][2]int{{3, 4}}, linked)
+		if path, err := EulerPath(g); err != nil || len(path) != 2 {
+			t.Errorf(name+": EulerPath of a single edge should have 2 vertices but is %v, %v", path, err)
+		}
+	}
+}
+
+func testTopologicalSort(t *testing.T, name string, g Digraph) {
+
+	// a DAG of course prerequisites
+	edges := [][2]int{{0, 2}, {1, 2}, {1, 3}, {2, 4}, {3, 4}, {4, 5}, {6, 5}, {0, 6}}
+	for _, e := range edges {
+		g.AddEdge(e[0], e[1])
+	}
+	order, err := TopologicalSort(g)
+	if err != nil {
+		t.Errorf(name+": Topological sort of a DAG failed: %v", err)
+	}
+	if len(order) != g.Vertices() {
+		t.Fatalf(name+": Topological sort should have %v vertices but is %v", g.Vertices(), order)
+	}
+	position := make([]int, g.Vertices())
+	for i := range position {
+		position[i] = -1
+	}
+	for i, v := range order {
+		position[v] = i
+	}
+	for v, p := range position {
+		if p < 0 {
+			t.Errorf(name+": Topological sort %v is missing vertex %v", order, v)
+		}
+	}
+	for _, e := range edges {
+		if position[e[1]] < position[e[0]] {
+			t.Errorf(name+": Topological sort %v puts %v before %v", order, e[1], e[0])
+		}
+	}
+
+	// a cycle 5->7->4->5 makes ordering impossible
+	g.AddEdge(5, 7)
+	g.AddEdge(7, 4)
+	if order, err := TopologicalSort(g); err == nil {
+		t.Errorf(name+": Topological sort should detect a cycle but returned %v", order)
+	}
+}
+
 func samePath(p, q []int) bool {
 	if len(p) != len(q) {
 		return false
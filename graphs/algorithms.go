@@ -5,8 +5,10 @@
 
 package graphs
 
-import "containers"
+import "containers/queue"
+import "containers/stack"
 import "errors"
+import "math/rand"
 
 // Perform a recursive depth-first search of g starting at v0 and
 // applying the visit function to every vertex as it is visited.
@@ -45,7 +47,7 @@ type Edge struct {
 // Normal return: all vertices in g connected to v0 are visited once
 func StackDFS(g Graph, v0 int, visit func(Graph, int, int)) {
 	isVisited := make([]bool, g.Vertices())
-	stack := containers.NewLinkedStack()
+	stack := new(stack.LinkedStack)
 	stack.Push(Edge{-1, v0})
 	for edge, err := stack.Pop(); err == nil; edge, err = stack.Pop() {
 		v, w := edge.(Edge).v, edge.(Edge).w
@@ -70,7 +72,7 @@ func StackDFS(g Graph, v0 int, visit func(Graph, int, int)) {
 // Normal return: all vertices in g connected to v0 are visited once
 func BFS(g Graph, v0 int, visit func(Graph, int, int)) {
 	isVisited := make([]bool, g.Vertices())
-	queue := containers.NewLinkedQueue()
+	queue := new(queue.LinkedQueue)
 	queue.Enter(Edge{-1, v0})
 	for edge, err := queue.Leave(); err == nil; edge, err = queue.Leave() {
 		v, w := edge.(Edge).v, edge.(Edge).w
@@ -88,6 +90,34 @@ func BFS(g Graph, v0 int, visit func(Graph, int, int)) {
 	}
 }
 
+// Return the vertices of g reachable from source in the order that a
+// breadth-first search from source first visits them.
+// Pre: source is in g
+// Pre violation: return nil
+// Normal return: the visited vertices, starting with source
+func BFSOrder(g Graph, source int) []int {
+	if source < 0 || g.Vertices() <= source {
+		return nil
+	}
+	result := make([]int, 0, g.Vertices())
+	BFS(g, source, func(g Graph, v, w int) { result = append(result, w) })
+	return result
+}
+
+// Return the vertices of g reachable from source in the order that a
+// depth-first search from source first visits them.
+// Pre: source is in g
+// Pre violation: return nil
+// Normal return: the visited vertices, starting with source
+func DFSOrder(g Graph, source int) []int {
+	if source < 0 || g.Vertices() <= source {
+		return nil
+	}
+	result := make([]int, 0, g.Vertices())
+	DFS(g, source, func(g Graph, v, w int) { result = append(result, w) })
+	return result
+}
+
 // Return true iff there is a path between v and w in g.
 func IsPath(g Graph, v, w int) bool {
 	if v < 0 || g.Vertices() <= v {
@@ -106,6 +136,26 @@ func IsPath(g Graph, v, w int) bool {
 	return isReached
 }
 
+// Return true iff adding an edge between v and w to g would form a cycle,
+// which is the case iff v and w are already connected.
+func WouldCreateCycle(g Graph, v, w int) bool {
+	return IsPath(g, v, w)
+}
+
+// Return a matrix whose [v][w] entry is true iff there is a path from v to w
+// in g; like IsPath, every vertex has a path to itself. A search from each
+// vertex fills in its row, so answering many path queries costs one pass
+// over the graph per vertex rather than one per query.
+func TransitiveClosure(g Graph) [][]bool {
+	result := make([][]bool, g.Vertices())
+	for v := range result {
+		row := make([]bool, g.Vertices())
+		BFS(g, v, func(g Graph, v1, v2 int) { row[v2] = true })
+		result[v] = row
+	}
+	return result
+}
+
 // Return an int slice with the shortest path between v and w.
 // Pre: IsPath(g,v,w)
 // Pre violation: Return nil and an error
@@ -139,6 +189,40 @@ func IsConnected(g Graph) bool {
 	return vertexCount == g.Vertices()
 }
 
+// Return true iff g is bipartite, that is, its vertices can be colored with
+// two colors so that no edge joins vertices of the same color. Each component
+// is colored in turn by a breadth-first search that alternates colors level by
+// level; an edge between two vertices of the same color means g is not
+// bipartite.
+func IsBipartite(g Graph) bool {
+	const uncolored = -1
+	color := make([]int, g.Vertices())
+	for v := range color {
+		color[v] = uncolored
+	}
+	queue := new(queue.LinkedQueue)
+	for s := 0; s < g.Vertices(); s++ {
+		if color[s] != uncolored {
+			continue
+		}
+		color[s] = 0
+		queue.Enter(s)
+		for e, err := queue.Leave(); err == nil; e, err = queue.Leave() {
+			v := e.(int)
+			iter, _ := g.NewIterator(v)
+			for w, ok := iter.Next(); ok; w, ok = iter.Next() {
+				if color[w] == uncolored {
+					color[w] = 1 - color[v]
+					queue.Enter(w)
+				} else if color[w] == color[v] {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
 // Return a new linked graph containing a spanning tree for g.
 // Pre: g is connected.
 // Pre Violation: return nil and false.
@@ -160,13 +244,8 @@ func SpanningTree(g Graph) (Graph, error) {
 func MaxDegree(g Graph) int {
 	result := 0
 	visit := func(g Graph, v1, v2 int) {
-		iter, _ := g.NewIterator(v2)
-		count := 0
-		for _, ok := iter.Next(); ok; _, ok = iter.Next() {
-			count++
-		}
-		if result < count {
-			result = count
+		if degree, _ := g.Degree(v2); result < degree {
+			result = degree
 		}
 	}
 	DFS(g, 0, visit)
@@ -185,3 +264,343 @@ func NumConnectedVertices(g Graph, v int) int {
 	DFS(g, v, visit)
 	return result
 }
+
+// Return the length of the shortest cycle in the undirected graph g, or -1 if
+// g has no cycles. A breadth-first search from each vertex finds the shortest
+// cycle through it: the first non-tree edge v-w seen closes a cycle of length
+// dist[v]+dist[w]+1, and the minimum over all starting vertices is exact.
+func Girth(g Graph) int {
+	result := -1
+	for s := 0; s < g.Vertices(); s++ {
+		dist := make([]int, g.Vertices())
+		parent := make([]int, g.Vertices())
+		for v := range dist {
+			dist[v] = -1
+		}
+		dist[s], parent[s] = 0, -1
+		queue := new(queue.LinkedQueue)
+		queue.Enter(s)
+		for e, err := queue.Leave(); err == nil; e, err = queue.Leave() {
+			v := e.(int)
+			iter, _ := g.NewIterator(v)
+			for w, ok := iter.Next(); ok; w, ok = iter.Next() {
+				if dist[w] < 0 {
+					dist[w], parent[w] = dist[v]+1, v
+					queue.Enter(w)
+				} else if w != parent[v] {
+					if length := dist[v] + dist[w] + 1; result < 0 || length < result {
+						result = length
+					}
+				}
+			}
+		}
+	}
+	return result
+}
+
+// Return the greatest distance (number of edges on a shortest path) from v
+// to any other vertex in g, found by a breadth-first search from v, or -1 if
+// some vertex cannot be reached from v.
+// Pre: v is in g
+// Pre violation: return -1
+// Normal return: the eccentricity of v
+func Eccentricity(g Graph, v int) int {
+	if v < 0 || g.Vertices() <= v {
+		return -1
+	}
+	dist := make([]int, g.Vertices())
+	for w := range dist {
+		dist[w] = -1
+	}
+	dist[v] = 0
+	result, reached := 0, 1
+	queue := new(queue.LinkedQueue)
+	queue.Enter(v)
+	for e, err := queue.Leave(); err == nil; e, err = queue.Leave() {
+		x := e.(int)
+		iter, _ := g.NewIterator(x)
+		for w, ok := iter.Next(); ok; w, ok = iter.Next() {
+			if dist[w] < 0 {
+				dist[w] = dist[x] + 1
+				result = dist[w]
+				reached++
+				queue.Enter(w)
+			}
+		}
+	}
+	if reached < g.Vertices() {
+		return -1
+	}
+	return result
+}
+
+// Return the diameter of g, the greatest distance between any two of its
+// vertices, which is the largest eccentricity of any vertex, or -1 if g is
+// not connected. This takes a breadth-first search from every vertex.
+func Diameter(g Graph) int {
+	result := 0
+	for v := 0; v < g.Vertices(); v++ {
+		e := Eccentricity(g, v)
+		if e < 0 {
+			return -1
+		}
+		if result < e {
+			result = e
+		}
+	}
+	return result
+}
+
+// Return the articulation points (cut vertices) of g in ascending order: the
+// vertices whose removal would increase the number of connected components.
+// A depth-first search records the time each vertex is discovered and the
+// earliest discovery time low[v] reachable from the subtree rooted at v using
+// at most one non-tree edge. A non-root vertex v is a cut vertex iff some child
+// w has low[w] >= discovered[v]; a root is one iff it has two or more children.
+func ArticulationPoints(g Graph) []int {
+	discovered := make([]int, g.Vertices()) // 0 means not yet discovered
+	low := make([]int, g.Vertices())
+	isCut := make([]bool, g.Vertices())
+	time := 0
+	var dfs func(v, parent int)
+	dfs = func(v, parent int) {
+		time++
+		discovered[v], low[v] = time, time
+		children := 0
+		iter, _ := g.NewIterator(v)
+		for w, ok := iter.Next(); ok; w, ok = iter.Next() {
+			if discovered[w] == 0 {
+				children++
+				dfs(w, v)
+				if low[w] < low[v] {
+					low[v] = low[w]
+				}
+				if parent != -1 && discovered[v] <= low[w] {
+					isCut[v] = true
+				}
+			} else if w != parent && discovered[w] < low[v] {
+				low[v] = discovered[w]
+			}
+		}
+		if parent == -1 && 1 < children {
+			isCut[v] = true
+		}
+	}
+	for v := 0; v < g.Vertices(); v++ {
+		if discovered[v] == 0 {
+			dfs(v, -1)
+		}
+	}
+	result := []int{}
+	for v, cut := range isCut {
+		if cut {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// Return a proper coloring of g, with a color (0, 1, ...) for each vertex such
+// that no two adjacent vertices have the same color. Vertices are colored
+// greedily in index order, each getting the smallest color not used by an
+// already colored neighbor, so at most MaxDegree+1 colors are used, though
+// this may be many more than the fewest possible.
+func GreedyColoring(g Graph) []int {
+	const uncolored = -1
+	result := make([]int, g.Vertices())
+	for v := range result {
+		result[v] = uncolored
+	}
+	for v := range result {
+		isUsed := make([]bool, g.Vertices()+1)
+		iter, _ := g.NewIterator(v)
+		for w, ok := iter.Next(); ok; w, ok = iter.Next() {
+			if result[w] != uncolored {
+				isUsed[result[w]] = true
+			}
+		}
+		color := 0
+		for isUsed[color] {
+			color++
+		}
+		result[v] = color
+	}
+	return result
+}
+
+// Return the number of colors used in a coloring whose colors are numbered
+// consecutively from 0, as those from GreedyColoring are.
+func ColorCount(coloring []int) int {
+	result := 0
+	for _, color := range coloring {
+		if result <= color {
+			result = color + 1
+		}
+	}
+	return result
+}
+
+// Return true iff g has an Euler circuit, a closed walk using every edge
+// exactly once: every vertex has even degree and all the vertices with edges
+// are connected. A graph with no edges has an (empty) Euler circuit.
+func HasEulerCircuit(g Graph) bool {
+	return len(oddDegreeVertices(g)) == 0 && edgesAreConnected(g)
+}
+
+// Return true iff g has an Euler path, a walk using every edge exactly once:
+// no vertex or exactly two vertices have odd degree, and all the vertices with
+// edges are connected. Every Euler circuit is also an Euler path.
+func HasEulerPath(g Graph) bool {
+	odd := len(oddDegreeVertices(g))
+	return (odd == 0 || odd == 2) && edgesAreConnected(g)
+}
+
+// Return the vertices along an Euler path in g, found using Hierholzer's
+// algorithm. The path starts at a vertex of odd degree if there is one, and
+// is a circuit (ending where it starts) otherwise. The edges of a copy of g
+// are removed as they are followed; whenever the walk reaches a vertex with
+// no edges left, that vertex is done and the walk backs up, splicing in the
+// detours it finds on the way back.
+// Pre: HasEulerPath(g) and g has at least one vertex
+// Pre violation: return nil and an error
+// Normal return: the path (Edges()+1 vertices) and nil
+func EulerPath(g Graph) ([]int, error) {
+	if g.Vertices() == 0 || !HasEulerPath(g) {
+		return nil, errors.New("The graph has no Euler path")
+	}
+	start := 0
+	if odd := oddDegreeVertices(g); 0 < len(odd) {
+		start = odd[0]
+	} else {
+		for v := 0; v < g.Vertices(); v++ {
+			if degree, _ := g.Degree(v); 0 < degree {
+				start = v
+				break
+			}
+		}
+	}
+	remaining := NewLinkedGraph(g.Vertices())
+	for v := 0; v < g.Vertices(); v++ {
+		iter, _ := g.NewIterator(v)
+		for w, ok := iter.Next(); ok; w, ok = iter.Next() {
+			remaining.AddEdge(v, w)
+		}
+	}
+	result := make([]int, 0, g.Edges()+1)
+	stack := new(stack.LinkedStack)
+	stack.Push(start)
+	for !stack.Empty() {
+		top, _ := stack.Top()
+		v := top.(int)
+		iter, _ := remaining.NewIterator(v)
+		if w, ok := iter.Next(); ok {
+			remaining.RemoveEdge(v, w)
+			stack.Push(w)
+		} else {
+			stack.Pop()
+			result = append(result, v)
+		}
+	}
+	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+		result[i], result[j] = result[j], result[i]
+	}
+	return result, nil
+}
+
+// Return the vertices of a directed acyclic graph g in topological order, so
+// that every edge goes from a vertex earlier in the order to a later one. This
+// is Kahn's algorithm: vertices with no incoming edges from unordered vertices
+// wait in a queue, and each one output reduces the in-degrees of its targets.
+// Pre: g has no cycles
+// Pre violation: return nil and an error
+// Normal return: the ordered vertices and nil
+func TopologicalSort(g Digraph) ([]int, error) {
+	inDegree := make([]int, g.Vertices())
+	for v := 0; v < g.Vertices(); v++ {
+		iter, _ := g.NewIterator(v)
+		for w, ok := iter.Next(); ok; w, ok = iter.Next() {
+			inDegree[w]++
+		}
+	}
+	ready := new(queue.LinkedQueue)
+	for v, d := range inDegree {
+		if d == 0 {
+			ready.Enter(v)
+		}
+	}
+	result := make([]int, 0, g.Vertices())
+	for e, err := ready.Leave(); err == nil; e, err = ready.Leave() {
+		v := e.(int)
+		result = append(result, v)
+		iter, _ := g.NewIterator(v)
+		for w, ok := iter.Next(); ok; w, ok = iter.Next() {
+			inDegree[w]--
+			if inDegree[w] == 0 {
+				ready.Enter(w)
+			}
+		}
+	}
+	if len(result) < g.Vertices() {
+		return nil, errors.New("The digraph has a cycle")
+	}
+	return result, nil
+}
+
+// Return the vertices visited by a random walk of at most steps steps in g
+// from start, in which each step goes to a neighbor of the current vertex
+// chosen uniformly at random by a generator seeded with seed. The walk stops
+// early at a vertex with no neighbors.
+// Pre: start is in g
+// Pre violation: return nil
+// Normal return: the visited vertices, starting with start
+func RandomWalk(g Graph, start int, steps int, seed int64) []int {
+	if start < 0 || g.Vertices() <= start {
+		return nil
+	}
+	r := rand.New(rand.NewSource(seed))
+	result := []int{start}
+	var neighbors []int
+	for v := start; len(result) <= steps; {
+		neighbors = neighbors[:0]
+		iter, _ := g.NewIterator(v)
+		for w, ok := iter.Next(); ok; w, ok = iter.Next() {
+			neighbors = append(neighbors, w)
+		}
+		if len(neighbors) == 0 {
+			break
+		}
+		v = neighbors[r.Intn(len(neighbors))]
+		result = append(result, v)
+	}
+	return result
+}
+
+///////////////////////////////////////////////////////////////////////////////////////
+// Helper functions
+
+// Return the vertices of g with odd degree, in ascending order.
+func oddDegreeVertices(g Graph) []int {
+	result := []int{}
+	for v := 0; v < g.Vertices(); v++ {
+		if degree, _ := g.Degree(v); degree%2 == 1 {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// Return true iff every vertex of g with at least one edge is in the same
+// connected component.
+func edgesAreConnected(g Graph) bool {
+	start, withEdges := -1, 0
+	for v := 0; v < g.Vertices(); v++ {
+		if degree, _ := g.Degree(v); 0 < degree {
+			start = v
+			withEdges++
+		}
+	}
+	if start < 0 {
+		return true
+	}
+	return NumConnectedVertices(g, start)+1 == withEdges
+}
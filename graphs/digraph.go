@@ -0,0 +1,224 @@
+// digraph.go: This file contains the declarations for directed graphs. It
+// includes the Digraph interface and the arrayDigraph and linkedDigraph types
+// as receivers that implement the adjacency matrix and adjacency list
+// representations of directed graphs, respectively. Both reuse the undirected
+// representations, whose adjacency structures already record edges by source
+// vertex, and differ only in adding and removing a single entry per edge.
+//
+// author: C. Fox
+// version: 11/2013
+
+package graphs
+
+import "errors"
+
+// Digraph is the interface for directed graphs. Edges go from v to w, so
+// IsEdge(v,w) does not imply IsEdge(w,v), and iterators over the vertices
+// adjacent to v produce only the targets of edges out of v; likewise, Degree
+// is the same as OutDegree. Since a Digraph is a Graph, the search algorithms
+// work on digraphs as well, following edges in their direction.
+type Digraph interface {
+	Graph
+	InDegree(v int) (int, error)  // number of edges into v
+	OutDegree(v int) (int, error) // number of edges out of v
+}
+
+///////////////////////////////////////////////////////////////////////////////////////
+// arrayDigraph is the data structure for the adjacency matrix representation of a
+// digraph; adjacent[v][w] is true iff v->w is an edge.
+type arrayDigraph struct {
+	arrayGraph
+}
+
+// NewArrayDigraph returns a pointer to a digraph represented using an
+// adjacency matrix.
+// Pre: n > 0
+// Pre violation: return a digraph with 1 vertex.
+// Normal return: return a digraph with n vertices.
+func NewArrayDigraph(n int) *arrayDigraph {
+	result := new(arrayDigraph)
+	result.arrayGraph = *NewArrayGraph(n)
+	return result
+}
+
+// AddEdge puts a new edge v->w in the receiver digraph; it does nothing if
+// the edge is already there.
+// Pre: v and w are in the digraph.
+// Pre violation: return an error.
+// Normal return: add the edge and return nil.
+func (g *arrayDigraph) AddEdge(v, w int) error {
+	if w == v {
+		return errors.New("The edge vertices are not distinct")
+	}
+	if v < 0 || g.Vertices() <= v {
+		return errors.New("The source vertex is not in the graph")
+	}
+	if w < 0 || g.Vertices() <= w {
+		return errors.New("The target vertex is not in the graph")
+	}
+	if g.adjacent[v][w] {
+		return nil
+	}
+	g.adjacent[v][w] = true
+	g.numEdges++
+	return nil
+}
+
+// RemoveEdge takes edge v->w out of the receiver digraph; it does nothing
+// if the edge is not there.
+// Pre: v and w are in the digraph.
+// Pre violation: return an error.
+// Normal return: remove the edge and return nil.
+func (g *arrayDigraph) RemoveEdge(v, w int) error {
+	if v < 0 || g.Vertices() <= v {
+		return errors.New("The source vertex is not in the graph")
+	}
+	if w < 0 || g.Vertices() <= w {
+		return errors.New("The target vertex is not in the graph")
+	}
+	if !g.adjacent[v][w] {
+		return nil
+	}
+	g.adjacent[v][w] = false
+	g.numEdges--
+	return nil
+}
+
+// RemoveVertex takes v and all edges into and out of it out of the receiver
+// digraph; every vertex w > v becomes vertex w-1.
+// Pre: v is in the digraph.
+// Pre violation: return an error.
+// Normal return: remove the vertex and return nil.
+func (g *arrayDigraph) RemoveVertex(v int) error {
+	outDegree, err := g.OutDegree(v)
+	if err != nil {
+		return err
+	}
+	g.numEdges -= outDegree + g.removeVertex(v)
+	return nil
+}
+
+// InDegree returns the number of edges into v.
+// Pre: v is in the digraph.
+// Pre violation: return 0 and an error.
+// Normal return: return the in-degree of v and nil.
+func (g *arrayDigraph) InDegree(v int) (int, error) {
+	if v < 0 || g.Vertices() <= v {
+		return 0, errors.New("The vertex is not in the graph")
+	}
+	result := 0
+	for u := 0; u < g.Vertices(); u++ {
+		if g.adjacent[u][v] {
+			result++
+		}
+	}
+	return result, nil
+}
+
+// OutDegree returns the number of edges out of v.
+// Pre: v is in the digraph.
+// Pre violation: return 0 and an error.
+// Normal return: return the out-degree of v and nil.
+func (g *arrayDigraph) OutDegree(v int) (int, error) {
+	return g.Degree(v)
+}
+
+///////////////////////////////////////////////////////////////////////////////////////
+// linkedDigraph is the data structure for the adjacency lists representation of a
+// digraph; adjacent[v] holds w iff v->w is an edge.
+type linkedDigraph struct {
+	linkedGraph
+}
+
+// NewLinkedDigraph returns a pointer to a digraph represented using adjacency lists.
+// Pre: n > 0
+// Pre violation: return a digraph with 1 vertex.
+// Normal return: return a digraph with n vertices.
+func NewLinkedDigraph(n int) *linkedDigraph {
+	result := new(linkedDigraph)
+	result.linkedGraph = *NewLinkedGraph(n)
+	return result
+}
+
+// AddEdge puts a new edge v->w in the receiver digraph; it does nothing if
+// the edge is already there.
+// Pre: v and w are in the digraph.
+// Pre violation: return an error.
+// Normal return: add the edge and return nil.
+func (g *linkedDigraph) AddEdge(v, w int) error {
+	if w == v {
+		return errors.New("The edge vertices are not distinct")
+	}
+	if v < 0 || g.Vertices() <= v {
+		return errors.New("The source vertex is not in the graph")
+	}
+	if w < 0 || g.Vertices() <= w {
+		return errors.New("The target vertex is not in the graph")
+	}
+	if g.IsEdge(v, w) {
+		return nil
+	}
+	g.adjacent[v].Insert(0, Vertex(w))
+	g.numEdges++
+	return nil
+}
+
+// RemoveEdge takes edge v->w out of the receiver digraph; it does nothing
+// if the edge is not there.
+// Pre: v and w are in the digraph.
+// Pre violation: return an error.
+// Normal return: remove the edge and return nil.
+func (g *linkedDigraph) RemoveEdge(v, w int) error {
+	if v < 0 || g.Vertices() <= v {
+		return errors.New("The source vertex is not in the graph")
+	}
+	if w < 0 || g.Vertices() <= w {
+		return errors.New("The target vertex is not in the graph")
+	}
+	i, ok := g.adjacent[v].Index(Vertex(w))
+	if !ok {
+		return nil
+	}
+	g.adjacent[v].Delete(i)
+	g.numEdges--
+	return nil
+}
+
+// RemoveVertex takes v and all edges into and out of it out of the receiver
+// digraph; every vertex w > v becomes vertex w-1.
+// Pre: v is in the digraph.
+// Pre violation: return an error.
+// Normal return: remove the vertex and return nil.
+func (g *linkedDigraph) RemoveVertex(v int) error {
+	outDegree, err := g.OutDegree(v)
+	if err != nil {
+		return err
+	}
+	g.numEdges -= outDegree + g.removeVertex(v)
+	return nil
+}
+
+// InDegree returns the number of edges into v.
+// Pre: v is in the digraph.
+// Pre violation: return 0 and an error.
+// Normal return: return the in-degree of v and nil.
+func (g *linkedDigraph) InDegree(v int) (int, error) {
+	if v < 0 || g.Vertices() <= v {
+		return 0, errors.New("The vertex is not in the graph")
+	}
+	result := 0
+	for u := 0; u < g.Vertices(); u++ {
+		if g.adjacent[u].Contains(Vertex(v)) {
+			result++
+		}
+	}
+	return result, nil
+}
+
+// OutDegree returns the number of edges out of v.
+// Pre: v is in the digraph.
+// Pre violation: return 0 and an error.
+// Normal return: return the out-degree of v and nil.
+func (g *linkedDigraph) OutDegree(v int) (int, error) {
+	return g.Degree(v)
+}
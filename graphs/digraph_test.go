@@ -0,0 +1,148 @@
+// Test Digraph interface and the ArrayDigraph and LinkedDigraph data structures.
+// author: C. Fox
+// version: 11/2013
+
+package graphs
+
+import "testing"
+
+func TestDigraphs(t *testing.T) {
+	testDigraph(t, "ArrayDigraph", NewArrayDigraph(10))
+	testDigraph(t, "LinkedDigraph", NewLinkedDigraph(10))
+}
+
+func testDigraph(t *testing.T, name string, g Digraph) {
+
+	// make sure a new Digraph is the right size
+	if g.Vertices() != 10 || 0 != g.Edges() {
+		t.Errorf(name+" should have 10 vertices and no edges but has %v verties and %v edges", g.Vertices(), g.Edges())
+	}
+
+	// add some illegal edges
+	if err := g.AddEdge(2, 2); err == nil {
+		t.Error(name + ": Illegal edge 2->2 not detected")
+	}
+	if err := g.AddEdge(2, 200); err == nil {
+		t.Error(name + ": Illegal edge 2->200 not detected")
+	}
+	if err := g.AddEdge(200, 2); err == nil {
+		t.Error(name + ": Illegal edge 200->2 not detected")
+	}
+
+	// edges are directional
+	g.AddEdge(0, 1)
+	if !g.IsEdge(0, 1) {
+		t.Error(name + ": Edge 0->1 missing")
+	}
+	if g.IsEdge(1, 0) {
+		t.Error(name + ": Edge 0->1 should not imply edge 1->0")
+	}
+	g.AddEdge(0, 1)
+	if g.Edges() != 1 {
+		t.Errorf(name+": Edge count should be 1 but is %v", g.Edges())
+	}
+	g.AddEdge(1, 0)
+	if !g.IsEdge(1, 0) || g.Edges() != 2 {
+		t.Error(name + ": Edge 1->0 should be a separate edge from 0->1")
+	}
+
+	// in- and out-degrees
+	g.AddEdge(2, 1)
+	g.AddEdge(3, 1)
+	g.AddEdge(1, 4)
+	if d, _ := g.InDegree(1); d != 3 {
+		t.Errorf(name+": In-degree of 1 should be 3 but is %v", d)
+	}
+	if d, _ := g.OutDegree(1); d != 2 {
+		t.Errorf(name+": Out-degree of 1 should be 2 but is %v", d)
+	}
+	if d, _ := g.Degree(1); d != 2 {
+		t.Errorf(name+": Degree of 1 should be its out-degree 2 but is %v", d)
+	}
+	if d, _ := g.InDegree(2); d != 0 {
+		t.Errorf(name+": In-degree of 2 should be 0 but is %v", d)
+	}
+	if d, _ := g.OutDegree(4); d != 0 {
+		t.Errorf(name+": Out-degree of 4 should be 0 but is %v", d)
+	}
+	if _, err := g.InDegree(10); err == nil {
+		t.Error(name + ": Failed to detect illegal vertex 10 for InDegree")
+	}
+	if _, err := g.OutDegree(-1); err == nil {
+		t.Error(name + ": Failed to detect illegal vertex -1 for OutDegree")
+	}
+
+	// removal is directional
+	if err := g.RemoveEdge(1, 10); err == nil {
+		t.Error(name + ": Illegal edge 1->10 removal not detected")
+	}
+	g.AddEdge(5, 6)
+	g.AddEdge(6, 5)
+	if err := g.RemoveEdge(5, 6); err != nil {
+		t.Errorf(name+": Removing edge 5->6 failed: %v", err)
+	}
+	if g.IsEdge(5, 6) || !g.IsEdge(6, 5) {
+		t.Error(name + ": Removing edge 5->6 should leave edge 6->5")
+	}
+	g.RemoveEdge(5, 6)
+	g.RemoveEdge(6, 5)
+	if g.Edges() != 5 {
+		t.Errorf(name+": Edge count should be 5 but is %v", g.Edges())
+	}
+
+	// removing a vertex removes edges in both directions
+	v := g.AddVertex()
+	g.AddEdge(v, 2)
+	g.AddEdge(3, v)
+	g.AddEdge(v, 9)
+	if g.Vertices() != 11 || g.Edges() != 8 {
+		t.Errorf(name+": After adding vertex 10 there should be 11 vertices and 8 edges but there are %v and %v",
+			g.Vertices(), g.Edges())
+	}
+	if err := g.RemoveVertex(v); err != nil || g.Vertices() != 10 || g.Edges() != 5 {
+		t.Errorf(name+": After removing vertex 10 there should be 10 vertices and 5 edges but there are %v and %v",
+			g.Vertices(), g.Edges())
+	}
+	g.AddVertex()
+	g.AddEdge(10, 9)
+	g.AddEdge(9, 10)
+	g.RemoveVertex(8)
+	if !g.IsEdge(8, 9) || !g.IsEdge(9, 8) || !g.IsEdge(0, 1) || g.Edges() != 7 {
+		t.Errorf(name+": Removing vertex 8 should renumber edges 9<->10 to 8<->9:\n%v", g)
+	}
+	g.RemoveVertex(9)
+	g.AddVertex()
+	if g.Edges() != 5 {
+		t.Errorf(name+": Edge count should be 5 but is %v", g.Edges())
+	}
+
+	// iteration yields only out-neighbors
+	if _, err := g.NewIterator(-1); err == nil {
+		t.Error(name + ": Failed to detect illegal vertex -1")
+	}
+	found := make([]bool, g.Vertices())
+	iter, _ := g.NewIterator(1)
+	for w, ok := iter.Next(); ok; w, ok = iter.Next() {
+		found[w] = true
+	}
+	for w, expected := range []bool{true, false, false, false, true, false, false, false, false, false} {
+		if found[w] != expected {
+			t.Errorf(name+": Iteration over 1 should produce %v only if %v", w, expected)
+		}
+	}
+
+	// searches follow edges in their direction
+	if !IsPath(g, 3, 4) {
+		t.Error(name + ": There is a path from 3 to 4")
+	}
+	if IsPath(g, 4, 3) {
+		t.Error(name + ": There is no path from 4 to 3")
+	}
+	counts := make([]int, g.Vertices())
+	BFS(g, 2, func(g Graph, v, w int) { counts[w]++ })
+	for w, expected := range []int{1, 1, 1, 0, 1, 0, 0, 0, 0, 0} {
+		if counts[w] != expected {
+			t.Errorf(name+": BFS from 2 should visit %v %v times but visited it %v times", w, expected, counts[w])
+		}
+	}
+}
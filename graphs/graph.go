@@ -2,26 +2,33 @@
 // package. In particular, it includes the Graph and Iterator interfaces,
 // and the arrayGraph and linkedGraph types as receivers that implement the
 // adjacency matrix and adjacency list representations of undirected graphs,
-// respectively.
+// respectively. Vertices may be added and removed after construction;
+// removing vertex v renumbers every vertex w > v as w-1, so vertices are
+// always numbered 0..Vertices()-1.
 //
 // author: C. Fox
 // version: 11/2013
 
-// Package graphs implements basic undirected graphs using both the adjacency
-// matrix and adjacency list representations.
+// Package graphs implements basic undirected and directed graphs using both
+// the adjacency matrix and adjacency list representations.
 
 package graphs
 
-import "containers" // use a linked list in the linked graph representation
-import "errors"     // for illegal vertices and like errors
-import "fmt"        // for the String function
+import "containers"      // for list iterators
+import "containers/list" // use a linked list in the linked graph representation
+import "errors"          // for illegal vertices and like errors
+import "fmt"             // for the String function
 
 // Graph is the interface for undirected graphs.
 type Graph interface {
 	Edges() int                          // return the number of items in the container
 	Vertices() int                       // return the number of items in the container
 	AddEdge(v, w int) error              // add an edge between vertices v and w
+	RemoveEdge(v, w int) error           // remove the edge between vertices v and w
 	IsEdge(v, w int) bool                // true iff there is an edge between v and w
+	Degree(v int) (int, error)           // return the number of edges adjacent to v
+	AddVertex() int                      // add a new isolated vertex and return its number
+	RemoveVertex(v int) error            // remove v and its edges, renumbering higher vertices
 	NewIterator(v int) (Iterator, error) // make an iterator over edges adjacent to v
 }
 
@@ -90,6 +97,27 @@ func (g *arrayGraph) AddEdge(v, w int) error {
 	return nil
 }
 
+// RemoveEdge takes an edge out of the receiver graph; it does nothing if
+// the edge is not there.
+// Pre: v and w are in the graph.
+// Pre violation: return an error.
+// Normal return: remove the edge and return nil.
+func (g *arrayGraph) RemoveEdge(v, w int) error {
+	if v < 0 || g.Vertices() <= v {
+		return errors.New("The source vertex is not in the graph")
+	}
+	if w < 0 || g.Vertices() <= w {
+		return errors.New("The target vertex is not in the graph")
+	}
+	if !g.adjacent[v][w] {
+		return nil
+	}
+	g.adjacent[v][w] = false
+	g.adjacent[w][v] = false
+	g.numEdges--
+	return nil
+}
+
 // IsEdge determines whether the receiver graph contains edge {v,w}
 func (g *arrayGraph) IsEdge(v, w int) bool {
 	if w == v {
@@ -104,6 +132,62 @@ func (g *arrayGraph) IsEdge(v, w int) bool {
 	return g.adjacent[v][w]
 }
 
+// Degree returns the number of edges adjacent to v.
+// Pre: v is in the graph.
+// Pre violation: return 0 and an error.
+// Normal return: return the degree of v and nil.
+func (g *arrayGraph) Degree(v int) (int, error) {
+	if v < 0 || g.Vertices() <= v {
+		return 0, errors.New("The vertex is not in the graph")
+	}
+	result := 0
+	for _, isAdjacent := range g.adjacent[v] {
+		if isAdjacent {
+			result++
+		}
+	}
+	return result, nil
+}
+
+// AddVertex puts a new vertex with no edges in the receiver graph.
+// Normal return: return the number of the new vertex, which is the
+// old number of vertices.
+func (g *arrayGraph) AddVertex() int {
+	n := g.Vertices()
+	for v := range g.adjacent {
+		g.adjacent[v] = append(g.adjacent[v], false)
+	}
+	g.adjacent = append(g.adjacent, make([]bool, n+1))
+	return n
+}
+
+// RemoveVertex takes v and all its edges out of the receiver graph; every
+// vertex w > v becomes vertex w-1.
+// Pre: v is in the graph.
+// Pre violation: return an error.
+// Normal return: remove the vertex and return nil.
+func (g *arrayGraph) RemoveVertex(v int) error {
+	if v < 0 || g.Vertices() <= v {
+		return errors.New("The vertex is not in the graph")
+	}
+	g.numEdges -= g.removeVertex(v)
+	return nil
+}
+
+// removeVertex deletes row and column v from the adjacency matrix and
+// returns the number of edges into v that were deleted.
+func (g *arrayGraph) removeVertex(v int) int {
+	result := 0
+	for u := range g.adjacent {
+		if g.adjacent[u][v] {
+			result++
+		}
+		g.adjacent[u] = append(g.adjacent[u][:v], g.adjacent[u][v+1:]...)
+	}
+	g.adjacent = append(g.adjacent[:v], g.adjacent[v+1:]...)
+	return result
+}
+
 // NewIterator returns an iterator over the vertices adjacent to v.
 // Pre: 0 <= v <= g.Vertices()
 // Pre violation: return nil and false.
@@ -175,8 +259,8 @@ func (iter *arrayGraphIterator) Next() (int, bool) {
 ///////////////////////////////////////////////////////////////////////////////////////
 // linkedGraph is the data structure for the adjacency lists representation of a graph.
 type linkedGraph struct {
-	numEdges int               // in the graph
-	adjacent []containers.List // linked list of vertices adjacent to [v]
+	numEdges int         // in the graph
+	adjacent []list.List // linked list of vertices adjacent to [v]
 }
 
 // NewLinkedGraph returns a pointer to a graph represented using adjacency lists.
@@ -188,9 +272,9 @@ func NewLinkedGraph(n int) *linkedGraph {
 	if n < 0 {
 		n = 1
 	}
-	result.adjacent = make([]containers.List, n)
+	result.adjacent = make([]list.List, n)
 	for i := 0; i < n; i++ {
-		result.adjacent[i] = containers.NewLinkedList()
+		result.adjacent[i] = new(list.LinkedList)
 	}
 	return result
 }
@@ -232,6 +316,30 @@ func (g *linkedGraph) AddEdge(v, w int) error {
 	return nil
 }
 
+// RemoveEdge takes an edge out of the receiver graph; it does nothing if
+// the edge is not there.
+// Pre: v and w are in the graph.
+// Pre violation: return an error.
+// Normal return: remove the edge and return nil.
+func (g *linkedGraph) RemoveEdge(v, w int) error {
+	if v < 0 || g.Vertices() <= v {
+		return errors.New("The source vertex is not in the graph")
+	}
+	if w < 0 || g.Vertices() <= w {
+		return errors.New("The target vertex is not in the graph")
+	}
+	i, ok := g.adjacent[v].Index(Vertex(w))
+	if !ok {
+		return nil
+	}
+	g.adjacent[v].Delete(i)
+	if i, ok = g.adjacent[w].Index(Vertex(v)); ok {
+		g.adjacent[w].Delete(i)
+	}
+	g.numEdges--
+	return nil
+}
+
 // IsEdge determines whether the receiver graph contains edge {v,w}
 func (g *linkedGraph) IsEdge(v, w int) bool {
 	if w == v {
@@ -246,6 +354,62 @@ func (g *linkedGraph) IsEdge(v, w int) bool {
 	return g.adjacent[v].Contains(Vertex(w))
 }
 
+// Degree returns the number of edges adjacent to v.
+// Pre: v is in the graph.
+// Pre violation: return 0 and an error.
+// Normal return: return the degree of v and nil.
+func (g *linkedGraph) Degree(v int) (int, error) {
+	if v < 0 || g.Vertices() <= v {
+		return 0, errors.New("The vertex is not in the graph")
+	}
+	return g.adjacent[v].Size(), nil
+}
+
+// AddVertex puts a new vertex with no edges in the receiver graph.
+// Normal return: return the number of the new vertex, which is the
+// old number of vertices.
+func (g *linkedGraph) AddVertex() int {
+	g.adjacent = append(g.adjacent, new(list.LinkedList))
+	return len(g.adjacent) - 1
+}
+
+// RemoveVertex takes v and all its edges out of the receiver graph; every
+// vertex w > v becomes vertex w-1.
+// Pre: v is in the graph.
+// Pre violation: return an error.
+// Normal return: remove the vertex and return nil.
+func (g *linkedGraph) RemoveVertex(v int) error {
+	if v < 0 || g.Vertices() <= v {
+		return errors.New("The vertex is not in the graph")
+	}
+	g.numEdges -= g.removeVertex(v)
+	return nil
+}
+
+// removeVertex deletes the list of vertices adjacent to v, rebuilds every
+// other list without v and with higher vertices renumbered, and returns the
+// number of edges into v that were deleted.
+func (g *linkedGraph) removeVertex(v int) int {
+	result := 0
+	g.adjacent = append(g.adjacent[:v], g.adjacent[v+1:]...)
+	for u, adjacent := range g.adjacent {
+		renumbered := new(list.LinkedList)
+		iter := adjacent.NewIterator()
+		for e, ok := iter.Next(); ok; e, ok = iter.Next() {
+			switch w := e.(Vertex); {
+			case int(w) == v:
+				result++
+			case int(w) < v:
+				renumbered.Insert(renumbered.Size(), w)
+			default:
+				renumbered.Insert(renumbered.Size(), w-1)
+			}
+		}
+		g.adjacent[u] = renumbered
+	}
+	return result
+}
+
 // NewIterator returns an iterator over the vertices adjacent to v.
 // Pre: 0 <= v <= g.Vertices()
 // Pre violation: return nil and false.
@@ -276,7 +440,7 @@ func (iter *linkedGraphIterator) Reset() {
 
 // IsDone is true iff iteration is complete.
 func (iter *linkedGraphIterator) IsDone() bool {
-	return iter.listIter.IsDone()
+	return iter.listIter.Done()
 }
 
 // Next return the next adjacent vertex.
@@ -304,3 +468,27 @@ func (g *linkedGraph) String() string {
 	}
 	return result
 }
+
+///////////////////////////////////////////////////////////////////////////////////////
+// BuildGraph returns a graph with n vertices and the given edges, each a pair
+// of vertices, using the adjacency list representation if linked is true, and
+// the adjacency matrix representation otherwise.
+// Pre: n > 0 and every edge joins two distinct vertices in the graph
+// Pre violation: a graph with 1 vertex if n < 0; the graph holding
+// every legal edge and the error for the first illegal one
+// Normal return: the graph and nil
+func BuildGraph(n int, edges [][2]int, linked bool) (Graph, error) {
+	var result Graph
+	if linked {
+		result = NewLinkedGraph(n)
+	} else {
+		result = NewArrayGraph(n)
+	}
+	var firstErr error
+	for _, e := range edges {
+		if err := result.AddEdge(e[0], e[1]); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("Edge %v: %v", e, err)
+		}
+	}
+	return result, firstErr
+}
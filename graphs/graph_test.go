@@ -10,6 +10,12 @@ import "testing"
 func TestGraphs(t *testing.T) {
 	testGraph(t, "ArrayGraph", NewArrayGraph(20))
 	testGraph(t, "LinkedGraph", NewLinkedGraph(20))
+	testRemoveEdge(t, "ArrayGraph", NewArrayGraph(10))
+	testRemoveEdge(t, "LinkedGraph", NewLinkedGraph(10))
+	testDegree(t, "ArrayGraph", NewArrayGraph(10))
+	testDegree(t, "LinkedGraph", NewLinkedGraph(10))
+	testVertices(t, "ArrayGraph", NewArrayGraph(3))
+	testVertices(t, "LinkedGraph", NewLinkedGraph(3))
 }
 
 func testGraph(t *testing.T, name string, g Graph) {
@@ -21,13 +27,13 @@ func testGraph(t *testing.T, name string, g Graph) {
 
 	// add some illegal edges
 	if err := g.AddEdge(2, 2); err == nil {
-		t.Errorf(name + ": Illegal edge 2-2 detected")
+		t.Error(name + ": Illegal edge 2-2 detected")
 	}
 	if err := g.AddEdge(2, 200); err == nil {
-		t.Errorf(name + ": Illegal edge 2-200 detected")
+		t.Error(name + ": Illegal edge 2-200 detected")
 	}
 	if err := g.AddEdge(200, 2); err == nil {
-		t.Errorf(name + ": Illegal edge 200-2 detected")
+		t.Error(name + ": Illegal edge 200-2 detected")
 	}
 
 	// add some legal edges
@@ -48,7 +54,7 @@ func testGraph(t *testing.T, name string, g Graph) {
 
 	// test vertex iteration
 	if _, err := g.NewIterator(-1); err == nil {
-		t.Errorf(name + ": Failed to detect illegal vertex -1")
+		t.Error(name + ": Failed to detect illegal vertex -1")
 	}
 	if name == "ArrayGraph" {
 		i := 0
@@ -76,3 +82,182 @@ func testGraph(t *testing.T, name string, g Graph) {
 		}
 	}
 }
+
+func testRemoveEdge(t *testing.T, name string, g Graph) {
+	// remove some illegal edges
+	if err := g.RemoveEdge(2, 10); err == nil {
+		t.Error(name + ": Illegal edge 2-10 removal not detected")
+	}
+	if err := g.RemoveEdge(-1, 2); err == nil {
+		t.Error(name + ": Illegal edge -1-2 removal not detected")
+	}
+
+	// removing missing edges does nothing
+	if err := g.RemoveEdge(2, 3); err != nil {
+		t.Errorf(name+": Removing missing edge 2-3 failed: %v", err)
+	}
+	if err := g.RemoveEdge(2, 2); err != nil {
+		t.Errorf(name+": Removing missing edge 2-2 failed: %v", err)
+	}
+
+	// add some edges and remove them
+	g.AddEdge(2, 3)
+	g.AddEdge(2, 4)
+	g.AddEdge(5, 3)
+	if err := g.RemoveEdge(3, 2); err != nil {
+		t.Errorf(name+": Removing edge 3-2 failed: %v", err)
+	}
+	if g.IsEdge(2, 3) || g.IsEdge(3, 2) {
+		t.Error(name + ": Edge 2-3 should be gone in both directions")
+	}
+	if !g.IsEdge(2, 4) || !g.IsEdge(3, 5) {
+		t.Error(name + ": Removing edge 2-3 removed other edges")
+	}
+	if g.Edges() != 2 {
+		t.Errorf(name+": Edge count should be 2 but is %v", g.Edges())
+	}
+	g.RemoveEdge(2, 3)
+	if g.Edges() != 2 {
+		t.Errorf(name+": Removing edge 2-3 twice changed the edge count to %v", g.Edges())
+	}
+	g.RemoveEdge(2, 4)
+	g.RemoveEdge(5, 3)
+	if g.Edges() != 0 {
+		t.Errorf(name+": Edge count should be 0 but is %v", g.Edges())
+	}
+	iter, _ := g.NewIterator(3)
+	for w, ok := iter.Next(); ok; w, ok = iter.Next() {
+		t.Errorf(name+": Vertex 3 should have no adjacent vertices but has %v", w)
+	}
+	g.AddEdge(3, 2)
+	if !g.IsEdge(2, 3) || g.Edges() != 1 {
+		t.Error(name + ": Edge 2-3 should be addable after removal")
+	}
+}
+
+func testDegree(t *testing.T, name string, g Graph) {
+	if _, err := g.Degree(10); err == nil {
+		t.Error(name + ": Failed to detect illegal vertex 10 for Degree")
+	}
+	if _, err := g.Degree(-1); err == nil {
+		t.Error(name + ": Failed to detect illegal vertex -1 for Degree")
+	}
+	if d, err := g.Degree(0); err != nil || d != 0 {
+		t.Errorf(name+": Degree of 0 should be 0 but is %v", d)
+	}
+	g.AddEdge(0, 1)
+	g.AddEdge(0, 2)
+	g.AddEdge(3, 0)
+	g.AddEdge(0, 3)
+	g.AddEdge(1, 2)
+	expected := []int{3, 2, 2, 1, 0}
+	for v, e := range expected {
+		if d, _ := g.Degree(v); d != e {
+			t.Errorf(name+": Degree of %v should be %v but is %v", v, e, d)
+		}
+	}
+	g.RemoveEdge(2, 0)
+	g.RemoveEdge(1, 3)
+	g.AddEdge(4, 3)
+	expected = []int{2, 2, 1, 2, 1}
+	for v, e := range expected {
+		if d, _ := g.Degree(v); d != e {
+			t.Errorf(name+": After removals, degree of %v should be %v but is %v", v, e, d)
+		}
+	}
+}
+
+func testVertices(t *testing.T, name string, g Graph) {
+	g.AddEdge(0, 1)
+	g.AddEdge(1, 2)
+
+	// grow the graph and connect the new vertices
+	if v := g.AddVertex(); v != 3 || g.Vertices() != 4 {
+		t.Errorf(name+": New vertex should be 3 of 4 but is %v of %v", v, g.Vertices())
+	}
+	if v := g.AddVertex(); v != 4 || g.Vertices() != 5 {
+		t.Errorf(name+": New vertex should be 4 of 5 but is %v of %v", v, g.Vertices())
+	}
+	if d, err := g.Degree(4); err != nil || d != 0 {
+		t.Errorf(name+": New vertex 4 should have degree 0 but has %v", d)
+	}
+	if err := g.AddEdge(3, 4); err != nil {
+		t.Errorf(name+": Adding edge 3-4 between new vertices failed: %v", err)
+	}
+	g.AddEdge(0, 4)
+	g.AddEdge(2, 3)
+	if g.Edges() != 5 || !g.IsEdge(4, 3) || !g.IsEdge(4, 0) {
+		t.Errorf(name+": Edges to new vertices are missing:\n%v", g)
+	}
+
+	// remove vertex 1: 2, 3, and 4 become 1, 2, and 3
+	if err := g.RemoveVertex(5); err == nil {
+		t.Error(name + ": Failed to detect illegal vertex 5 for RemoveVertex")
+	}
+	if err := g.RemoveVertex(1); err != nil {
+		t.Errorf(name+": Removing vertex 1 failed: %v", err)
+	}
+	if g.Vertices() != 4 || g.Edges() != 3 {
+		t.Errorf(name+": After removing 1 there should be 4 vertices and 3 edges but there are %v and %v",
+			g.Vertices(), g.Edges())
+	}
+	expected := [][]bool{
+		{false, false, false, true},
+		{false, false, true, false},
+		{false, true, false, true},
+		{true, false, true, false}}
+	for v := range expected {
+		for w := range expected[v] {
+			if g.IsEdge(v, w) != expected[v][w] {
+				t.Errorf(name+": After removing 1, edge %v-%v should be %v", v, w, expected[v][w])
+			}
+		}
+	}
+	for v, e := range []int{1, 1, 2, 2} {
+		if d, _ := g.Degree(v); d != e {
+			t.Errorf(name+": After removing 1, degree of %v should be %v but is %v", v, e, d)
+		}
+	}
+
+	// removing the last vertex needs no renumbering
+	g.RemoveVertex(3)
+	if g.Vertices() != 3 || g.Edges() != 1 || !g.IsEdge(1, 2) {
+		t.Errorf(name+": After removing 3 only edge 1-2 should remain:\n%v", g)
+	}
+	if v := g.AddVertex(); v != 3 || g.IsEdge(0, 3) {
+		t.Error(name + ": Re-added vertex 3 should have no edges")
+	}
+}
+
+func TestBuildGraph(t *testing.T) {
+	edges := [][2]int{{0, 1}, {1, 2}, {2, 0}, {2, 3}, {3, 4}, {1, 0}}
+	adjacent := [][]int{{1, 2}, {0, 2}, {0, 1, 3}, {2, 4}, {3}, {}}
+	for _, linked := range []bool{false, true} {
+		g, err := BuildGraph(6, edges, linked)
+		if err != nil {
+			t.Errorf("BuildGraph (linked %v) fails: %v", linked, err)
+		}
+		if g.Vertices() != 6 || g.Edges() != 5 {
+			t.Errorf("BuildGraph (linked %v) should have 6 vertices and 5 edges but has %v and %v",
+				linked, g.Vertices(), g.Edges())
+		}
+		for v, expected := range adjacent {
+			if d, _ := g.Degree(v); d != len(expected) {
+				t.Errorf("BuildGraph (linked %v): vertex %v should have degree %v but has %v", linked, v, len(expected), d)
+			}
+			for _, w := range expected {
+				if !g.IsEdge(v, w) {
+					t.Errorf("BuildGraph (linked %v) should have edge %v-%v", linked, v, w)
+				}
+			}
+		}
+
+		g, err = BuildGraph(3, [][2]int{{0, 1}, {1, 5}, {2, 2}, {1, 2}}, linked)
+		if err == nil {
+			t.Errorf("BuildGraph (linked %v) should fail with an illegal edge", linked)
+		}
+		if g.Edges() != 2 || !g.IsEdge(0, 1) || !g.IsEdge(1, 2) {
+			t.Errorf("BuildGraph (linked %v) should still add the legal edges but has %v edges", linked, g.Edges())
+		}
+	}
+}
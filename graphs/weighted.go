@@ -0,0 +1,247 @@
+// weighted.go: This file contains the declarations for weighted undirected
+// graphs, including the WeightedGraph interface and the weightedGraph type,
+// which extends the adjacency matrix representation with edge weights, along
+// with Dijkstra's shortest path algorithm and Prim's minimum spanning tree
+// algorithm for weighted graphs.
+//
+// author: C. Fox
+// version: 11/2013
+
+package graphs
+
+import "errors"
+
+// Infinity is the distance Dijkstra reports for unreachable vertices.
+const Infinity = int(^uint(0) >> 1)
+
+// WeightedGraph is the interface for undirected graphs with integer edge weights.
+type WeightedGraph interface {
+	Edges() int                          // return the number of edges in the graph
+	Vertices() int                       // return the number of vertices in the graph
+	AddEdge(v, w, weight int) error      // add an edge between v and w with a weight
+	IsEdge(v, w int) bool                // true iff there is an edge between v and w
+	Weight(v, w int) (int, error)        // return the weight of the edge between v and w
+	AddVertex() int                      // add a new isolated vertex and return its number
+	RemoveVertex(v int) error            // remove v and its edges, renumbering higher vertices
+	NewIterator(v int) (Iterator, error) // make an iterator over edges adjacent to v
+}
+
+///////////////////////////////////////////////////////////////////////////////////////
+// weightedGraph is the data structure for the adjacency matrix representation of a
+// weighted graph; weight[v][w] is the weight of edge {v,w} if there is one.
+type weightedGraph struct {
+	arrayGraph
+	weight [][]int // weight of edge {v,w} at [v][w] and [w][v]
+}
+
+// NewWeightedGraph returns a pointer to a weighted graph represented using an
+// adjacency matrix.
+// Pre: n > 0
+// Pre violation: return a graph with 1 vertex.
+// Normal return: return a graph with n vertices.
+func NewWeightedGraph(n int) *weightedGraph {
+	result := new(weightedGraph)
+	result.arrayGraph = *NewArrayGraph(n)
+	result.weight = make([][]int, result.Vertices())
+	for i := range result.weight {
+		result.weight[i] = make([]int, result.Vertices())
+	}
+	return result
+}
+
+// AddEdge puts a new edge with the given weight in the receiver graph; if
+// the edge is already there, its weight is replaced.
+// Pre: v and w are in the graph and weight is not negative.
+// Pre violation: return an error.
+// Normal return: add the edge and return nil.
+func (g *weightedGraph) AddEdge(v, w, weight int) error {
+	if weight < 0 {
+		return errors.New("The edge weight is negative")
+	}
+	if err := g.arrayGraph.AddEdge(v, w); err != nil {
+		return err
+	}
+	g.weight[v][w] = weight
+	g.weight[w][v] = weight
+	return nil
+}
+
+// Weight returns the weight of edge {v,w}.
+// Pre: IsEdge(v,w)
+// Pre violation: return 0 and an error.
+// Normal return: return the weight and nil.
+func (g *weightedGraph) Weight(v, w int) (int, error) {
+	if !g.IsEdge(v, w) {
+		return 0, errors.New("The edge is not in the graph")
+	}
+	return g.weight[v][w], nil
+}
+
+// AddVertex puts a new vertex with no edges in the receiver graph.
+// Normal return: return the number of the new vertex.
+func (g *weightedGraph) AddVertex() int {
+	result := g.arrayGraph.AddVertex()
+	for v := range g.weight {
+		g.weight[v] = append(g.weight[v], 0)
+	}
+	g.weight = append(g.weight, make([]int, result+1))
+	return result
+}
+
+// RemoveVertex takes v and all its edges out of the receiver graph; every
+// vertex w > v becomes vertex w-1.
+// Pre: v is in the graph.
+// Pre violation: return an error.
+// Normal return: remove the vertex and return nil.
+func (g *weightedGraph) RemoveVertex(v int) error {
+	if err := g.arrayGraph.RemoveVertex(v); err != nil {
+		return err
+	}
+	for u := range g.weight {
+		g.weight[u] = append(g.weight[u][:v], g.weight[u][v+1:]...)
+	}
+	g.weight = append(g.weight[:v], g.weight[v+1:]...)
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////
+// Dijkstra's algorithm
+
+// Compute the lengths of the shortest paths from source to every vertex in g,
+// and the predecessor of each vertex on its shortest path. Unreachable vertices
+// have distance Infinity, and they and the source have predecessor -1.
+// Pre: source is in g
+// Pre violation: return nil and nil
+// Normal return: the distance and predecessor slices
+func Dijkstra(g WeightedGraph, source int) ([]int, []int) {
+	if source < 0 || g.Vertices() <= source {
+		return nil, nil
+	}
+	dist := make([]int, g.Vertices())
+	pred := make([]int, g.Vertices())
+	for v := range dist {
+		dist[v], pred[v] = Infinity, -1
+	}
+	isDone := make([]bool, g.Vertices())
+	dist[source] = 0
+	var frontier distanceHeap
+	frontier.push(source, 0)
+	for !frontier.empty() {
+		v := frontier.pop()
+		if isDone[v] {
+			continue // a stale entry from before a shorter path was found
+		}
+		isDone[v] = true
+		iter, _ := g.NewIterator(v)
+		for w, ok := iter.Next(); ok; w, ok = iter.Next() {
+			weight, _ := g.Weight(v, w)
+			if !isDone[w] && dist[v]+weight < dist[w] {
+				dist[w], pred[w] = dist[v]+weight, v
+				frontier.push(w, dist[w])
+			}
+		}
+	}
+	return dist, pred
+}
+
+///////////////////////////////////////////////////////////////////////////////////////
+// Prim's algorithm
+
+// Return a new weighted graph containing a minimum spanning tree for g. The
+// tree grows from vertex 0; the frontier heap holds candidate vertices keyed
+// on the weight of the lightest known edge joining them to the tree.
+// A graph with no vertices has an empty spanning tree.
+// Pre: g is connected
+// Pre violation: return nil and an error
+// Normal return: the spanning tree and nil
+func MinimumSpanningTree(g WeightedGraph) (WeightedGraph, error) {
+	if g.Vertices() == 0 {
+		return NewWeightedGraph(0), nil
+	}
+	cost := make([]int, g.Vertices())
+	pred := make([]int, g.Vertices())
+	for v := range cost {
+		cost[v], pred[v] = Infinity, -1
+	}
+	inTree := make([]bool, g.Vertices())
+	result := NewWeightedGraph(g.Vertices())
+	treeSize := 0
+	cost[0] = 0
+	var frontier distanceHeap
+	frontier.push(0, 0)
+	for !frontier.empty() {
+		v := frontier.pop()
+		if inTree[v] {
+			continue // a stale entry from before a lighter edge was found
+		}
+		inTree[v] = true
+		treeSize++
+		if pred[v] != -1 {
+			result.AddEdge(pred[v], v, cost[v])
+		}
+		iter, _ := g.NewIterator(v)
+		for w, ok := iter.Next(); ok; w, ok = iter.Next() {
+			weight, _ := g.Weight(v, w)
+			if !inTree[w] && weight < cost[w] {
+				cost[w], pred[w] = weight, v
+				frontier.push(w, weight)
+			}
+		}
+	}
+	if treeSize != g.Vertices() {
+		return nil, errors.New("Graph g is not connected")
+	}
+	return result, nil
+}
+
+// distanceHeap is a binary min-heap of vertices keyed on tentative distance.
+// Vertices may be pushed more than once; later entries have smaller keys.
+type distanceHeap struct {
+	vertex []int // vertices in heap order
+	key    []int // key[i] is the tentative distance of vertex[i]
+}
+
+// empty returns true iff the heap has no entries.
+func (h *distanceHeap) empty() bool { return len(h.vertex) == 0 }
+
+// push adds vertex v with key k and restores the heap property.
+func (h *distanceHeap) push(v, k int) {
+	h.vertex = append(h.vertex, v)
+	h.key = append(h.key, k)
+	for i := len(h.key) - 1; 0 < i; i = (i - 1) / 2 {
+		parent := (i - 1) / 2
+		if h.key[parent] <= h.key[i] {
+			break
+		}
+		h.swap(i, parent)
+	}
+}
+
+// pop removes and returns the vertex with the smallest key.
+// Pre: the heap is not empty.
+func (h *distanceHeap) pop() int {
+	result := h.vertex[0]
+	last := len(h.vertex) - 1
+	h.swap(0, last)
+	h.vertex, h.key = h.vertex[:last], h.key[:last]
+	for i := 0; ; {
+		smallest := i
+		for _, child := range []int{2*i + 1, 2*i + 2} {
+			if child < last && h.key[child] < h.key[smallest] {
+				smallest = child
+			}
+		}
+		if smallest == i {
+			break
+		}
+		h.swap(i, smallest)
+		i = smallest
+	}
+	return result
+}
+
+// swap exchanges entries i and j.
+func (h *distanceHeap) swap(i, j int) {
+	h.vertex[i], h.vertex[j] = h.vertex[j], h.vertex[i]
+	h.key[i], h.key[j] = h.key[j], h.key[i]
+}
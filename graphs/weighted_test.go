@@ -0,0 +1,154 @@
+// Test WeightedGraph interface, the weightedGraph data structure, and Dijkstra's
+// and Prim's algorithms.
+// author: C. Fox
+// version: 11/2013
+
+package graphs
+
+import "testing"
+
+func TestWeightedGraph(t *testing.T) {
+	g := NewWeightedGraph(5)
+	if g.Vertices() != 5 || g.Edges() != 0 {
+		t.Errorf("WeightedGraph should have 5 vertices and no edges but has %v and %v", g.Vertices(), g.Edges())
+	}
+	if err := g.AddEdge(0, 0, 1); err == nil {
+		t.Error("WeightedGraph: Illegal edge 0-0 not detected")
+	}
+	if err := g.AddEdge(0, 5, 1); err == nil {
+		t.Error("WeightedGraph: Illegal edge 0-5 not detected")
+	}
+	if err := g.AddEdge(0, 1, -1); err == nil {
+		t.Error("WeightedGraph: Negative weight not detected")
+	}
+	if g.Edges() != 0 {
+		t.Error("WeightedGraph: Illegal edges were added")
+	}
+	g.AddEdge(0, 1, 3)
+	g.AddEdge(1, 2, 4)
+	if w, err := g.Weight(1, 0); err != nil || w != 3 {
+		t.Errorf("WeightedGraph: Edge 1-0 should have weight 3 but has %v", w)
+	}
+	g.AddEdge(2, 1, 6)
+	if w, _ := g.Weight(1, 2); w != 6 || g.Edges() != 2 {
+		t.Errorf("WeightedGraph: Edge 1-2 should be replaced with weight 6 but has %v", w)
+	}
+	if _, err := g.Weight(0, 2); err == nil {
+		t.Error("WeightedGraph: Missing edge 0-2 should have no weight")
+	}
+}
+
+func TestWeightedGraphVertices(t *testing.T) {
+	g := NewWeightedGraph(3)
+	g.AddEdge(0, 1, 4)
+	g.AddEdge(1, 2, 5)
+	v := g.AddVertex()
+	if err := g.AddEdge(2, v, 6); err != nil {
+		t.Errorf("WeightedGraph: Adding edge 2-%v to a new vertex failed: %v", v, err)
+	}
+	if w, _ := g.Weight(v, 2); w != 6 {
+		t.Errorf("WeightedGraph: Edge %v-2 should have weight 6 but has %v", v, w)
+	}
+	g.RemoveVertex(0)
+	if g.Vertices() != 3 || g.Edges() != 2 {
+		t.Errorf("WeightedGraph: After removing 0 there should be 3 vertices and 2 edges but there are %v and %v",
+			g.Vertices(), g.Edges())
+	}
+	if w, _ := g.Weight(0, 1); w != 5 {
+		t.Errorf("WeightedGraph: Renumbered edge 0-1 should have weight 5 but has %v", w)
+	}
+	if w, _ := g.Weight(2, 1); w != 6 {
+		t.Errorf("WeightedGraph: Renumbered edge 2-1 should have weight 6 but has %v", w)
+	}
+}
+
+func TestDijkstra(t *testing.T) {
+	g := NewWeightedGraph(7)
+	edges := []struct{ v, w, weight int }{
+		{0, 1, 7}, {0, 2, 9}, {0, 5, 14}, {1, 2, 10}, {1, 3, 15},
+		{2, 3, 11}, {2, 5, 2}, {3, 4, 6}, {4, 5, 9}}
+	for _, e := range edges {
+		g.AddEdge(e.v, e.w, e.weight)
+	}
+
+	// vertex 6 is unreachable
+	dist, pred := Dijkstra(g, 0)
+	expectedDist := []int{0, 7, 9, 20, 20, 11, Infinity}
+	expectedPred := []int{-1, 0, 0, 2, 5, 2, -1}
+	for v := range expectedDist {
+		if dist[v] != expectedDist[v] {
+			t.Errorf("Dijkstra distance to %v should be %v but is %v", v, expectedDist[v], dist[v])
+		}
+		if pred[v] != expectedPred[v] {
+			t.Errorf("Dijkstra predecessor of %v should be %v but is %v", v, expectedPred[v], pred[v])
+		}
+	}
+
+	// from another source
+	dist, pred = Dijkstra(g, 3)
+	expectedDist = []int{20, 15, 11, 0, 6, 13, Infinity}
+	expectedPred = []int{2, 3, 3, -1, 3, 2, -1}
+	for v := range expectedDist {
+		if dist[v] != expectedDist[v] {
+			t.Errorf("Dijkstra distance from 3 to %v should be %v but is %v", v, expectedDist[v], dist[v])
+		}
+		if pred[v] != expectedPred[v] {
+			t.Errorf("Dijkstra predecessor of %v from 3 should be %v but is %v", v, expectedPred[v], pred[v])
+		}
+	}
+
+	if dist, pred := Dijkstra(g, 7); dist != nil || pred != nil {
+		t.Error("Dijkstra should fail for a source outside the graph")
+	}
+}
+
+func TestMinimumSpanningTree(t *testing.T) {
+	g := NewWeightedGraph(7)
+	edges := [][3]int{{0, 1, 7}, {0, 3, 5}, {1, 2, 8}, {1, 3, 9}, {1, 4, 7}, {2, 4, 5},
+		{3, 4, 15}, {3, 5, 6}, {4, 5, 8}, {4, 6, 9}, {5, 6, 11}}
+	for _, e := range edges {
+		g.AddEdge(e[0], e[1], e[2])
+	}
+	mst, err := MinimumSpanningTree(g)
+	if err != nil {
+		t.Fatalf("MinimumSpanningTree failed on a connected graph: %v", err)
+	}
+	if mst.Vertices() != g.Vertices() || mst.Edges() != g.Vertices()-1 {
+		t.Errorf("MinimumSpanningTree should have %v vertices and %v edges but has %v and %v",
+			g.Vertices(), g.Vertices()-1, mst.Vertices(), mst.Edges())
+	}
+	total := 0
+	for _, e := range edges {
+		if mst.IsEdge(e[0], e[1]) {
+			if w, _ := mst.Weight(e[0], e[1]); w != e[2] {
+				t.Errorf("MinimumSpanningTree edge %v-%v should have weight %v but has %v", e[0], e[1], e[2], w)
+			}
+			total += e[2]
+		}
+	}
+	if total != 39 {
+		t.Errorf("MinimumSpanningTree weight should be 39 but is %v", total)
+	}
+	for v := 0; v < g.Vertices(); v++ {
+		for w := 0; w < g.Vertices(); w++ {
+			if mst.IsEdge(v, w) && !g.IsEdge(v, w) {
+				t.Errorf("MinimumSpanningTree has edge %v-%v not in the graph", v, w)
+			}
+		}
+	}
+
+	// a disconnected graph has no spanning tree
+	h := NewWeightedGraph(4)
+	h.AddEdge(0, 1, 1)
+	h.AddEdge(2, 3, 1)
+	if _, err := MinimumSpanningTree(h); err == nil {
+		t.Error("MinimumSpanningTree should fail on a disconnected graph")
+	}
+}
+
+func TestMinimumSpanningTreeOfEmptyGraph(t *testing.T) {
+	mst, err := MinimumSpanningTree(NewWeightedGraph(0))
+	if err != nil || mst == nil || mst.Vertices() != 0 || mst.Edges() != 0 {
+		t.Errorf("MinimumSpanningTree of an empty graph should be empty but is %v, %v", mst, err)
+	}
+}
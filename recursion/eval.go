@@ -1,15 +1,20 @@
 // eval.go: This file contains recursive and stack-based algorithms for evaluating simple
 // prefix, infix, and postfix expressions held in strings. These expressions must have
 // only the operators +, -, *, /, and % (with their usual meanings in integer arithemetic),
-// and operands that are one digit long. There are no negative operands.  Infix
-// expressions may have parentheses. No white space is allowed in expressions.
+// and operands that are one digit long. There are no negative operands, but infix
+// expressions may have parentheses and a unary minus before any operand or
+// parenthesized subexpression. Spaces and tabs between characters are ignored.
+// EvalInfixRecursive and EvalInfixStack apply infix operators strictly from left
+// to right; EvalInfixPrecedence gives *, /, and % precedence over + and -, and
+// EvalInfixVars does too while also allowing single-letter variables as operands.
 
 package recursion
 
 import (
-	"containers"
+	"containers/stack"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -21,12 +26,18 @@ func isDigit(ch byte) bool {
 	return strings.ContainsRune("0123456789", rune(ch))
 }
 
+// Determine whether a character is a letter
+func isLetter(ch byte) bool {
+	return ('a' <= ch && ch <= 'z') || ('A' <= ch && ch <= 'Z')
+}
+
 // Determine whether a character is an operator
 func isOperator(ch byte) bool {
 	return strings.ContainsRune("+-*/%", rune(ch))
 }
 
-// Apply an operator designated by op to two arguments
+// Apply an operator designated by op to two arguments; division or modulo
+// by zero is reported as an error.
 func applyOperator(op byte, leftArg, rightArg int) (int, error) {
 	switch op {
 	case '+':
@@ -36,13 +47,18 @@ func applyOperator(op byte, leftArg, rightArg int) (int, error) {
 	case '*':
 		return leftArg * rightArg, nil
 	case '/':
+		if rightArg == 0 {
+			return 0, errors.New("Division by zero")
+		}
 		return leftArg / rightArg, nil
 	case '%':
+		if rightArg == 0 {
+			return 0, errors.New("Modulo by zero")
+		}
 		return leftArg % rightArg, nil
 	default:
 		return 0, errors.New(fmt.Sprintf("Bad character %c", op))
 	}
-	panic("Reached impossible spot")
 }
 
 //////////////////////////////////////////////////////////////////////////
@@ -54,10 +70,10 @@ func applyOperator(op byte, leftArg, rightArg int) (int, error) {
 // Pre violation: return 0 and an error indication
 // Normal return: the expression value and nil
 func EvalPrefixRecursive(s string) (int, error) {
-	current := NewTokenizer(s)
+	current := NewTokenizerSkipSpace(s)
 	result, err := evalPrefix(current)
 	if err == nil && current.Char != '$' {
-		return 0, errors.New("Extra characters at the end of the expression")
+		return 0, current.Errorf("Extra characters at the end of the expression")
 	}
 	return result, err
 }
@@ -69,7 +85,7 @@ func EvalPrefixRecursive(s string) (int, error) {
 // two operand expressions.
 func evalPrefix(current *Tokenizer) (int, error) {
 	if current.Char == '$' {
-		return 0, errors.New("Missing argument")
+		return 0, current.Errorf("Missing argument")
 	}
 
 	// handle the case of a single digit
@@ -111,9 +127,9 @@ func EvalPrefixStack(s string) (int, error) {
 	if len(s) == 0 {
 		return 0, errors.New("Missing argument")
 	}
-	current := NewTokenizer(s)
-	opStack := containers.NewLinkedStack()
-	valStack := containers.NewLinkedStack()
+	current := NewTokenizerSkipSpace(s)
+	opStack := new(stack.LinkedStack)
+	valStack := new(stack.LinkedStack)
 
 	// process the entire string unless an error is encountered
 	for current.Char != '$' {
@@ -126,11 +142,11 @@ func EvalPrefixStack(s string) (int, error) {
 			for err == nil && op == 'v' {
 				opStack.Pop()
 				if op, err = opStack.Pop(); err != nil {
-					return 0, errors.New("Missing operator")
+					return 0, current.Errorf("Missing operator")
 				}
 				var leftArg int // argument from the stack
 				if elem, err := valStack.Pop(); err != nil {
-					return 0, errors.New("Missing left argument")
+					return 0, current.Errorf("Missing left argument")
 				} else {
 					leftArg = elem.(int)
 				}
@@ -142,23 +158,23 @@ func EvalPrefixStack(s string) (int, error) {
 			valStack.Push(rightArg)
 			opStack.Push('v')
 		default:
-			return 0, errors.New(fmt.Sprintf("Illegal character %v", current.Char))
+			return 0, current.Errorf("Illegal character %c", current.Char)
 		}
 		current.Next()
 	}
 
 	// if all is well, v should be on the opStack and the result on the valStack
 	if op, err := opStack.Pop(); err != nil || op != 'v' {
-		return 0, errors.New("Missing argument")
+		return 0, current.Errorf("Missing argument")
 	}
-	if !opStack.IsEmpty() {
-		return 0, errors.New("Missing argument")
+	if !opStack.Empty() {
+		return 0, current.Errorf("Missing argument")
 	}
 	result, err := valStack.Pop()
 	if err != nil {
-		return 0, errors.New("Missing argument")
+		return 0, current.Errorf("Missing argument")
 	}
-	if !valStack.IsEmpty() {
+	if !valStack.Empty() {
 		return 0, errors.New("Too many arguments")
 	}
 	return result.(int), nil
@@ -168,60 +184,38 @@ func EvalPrefixStack(s string) (int, error) {
 // Infix: These functions evaluate an infix expression held in a string.
 
 // EvalInfixRecursive uses recursion to parse and evaluate an infix
-// expression.
+// expression. Operators all have the same precedence and are applied from
+// left to right, so 5+6*7-2 is 75; see EvalInfixPrecedence.
 // Pre: Expression in s is well formed
 // Pre violation: return 0 and an error indication
 // Normal return: the expression value and nil
 func EvalInfixRecursive(s string) (int, error) {
-	current := NewTokenizer(s)
+	current := NewTokenizerSkipSpace(s)
 	result, err := evalInfix(current)
 	if err == nil && current.Char != '$' {
-		return 0, errors.New("Extra characters at the end of the expression")
+		return 0, current.Errorf("Extra characters at the end of the expression")
 	}
 	return result, err
 }
 
 // evalInfix is a private function to parse and evaluate an infix expression
 // using recursion. The strategy is to appy operators to operands from left
-// to right, with recursive calls to handle parenthesized sub-expressions.
+// to right, with recursive calls to handle operands.
 func evalInfix(current *Tokenizer) (result int, err error) {
 	// get the left argument first
-	var leftArg int
-	if current.Char == '(' {
-		current.Next()
-		leftArg, err = evalInfix(current)
-		if err != nil {
-			return 0, err
-		}
-		if current.Char != ')' {
-			return 0, errors.New("Missing right parenthesis")
-		}
-	} else if isDigit(current.Char) {
-		leftArg = int(current.Char - '0')
-	} else {
-		return 0, errors.New("Missing left argument")
+	leftArg, err := evalInfixOperand(current, "Missing left argument")
+	if err != nil {
+		return 0, err
 	}
-	current.Next()
 
 	// apply the next operator to the following operand as long as there is one
 	for isOperator(current.Char) {
-		op, rightArg := current.Char, 0
+		op := current.Char
 		current.Next()
-		if current.Char == '(' {
-			current.Next()
-			rightArg, err = evalInfix(current)
-			if err != nil {
-				return 0, err
-			}
-			if current.Char != ')' {
-				return 0, errors.New("Missing right parenthesis")
-			}
-		} else if isDigit(current.Char) {
-			rightArg = int(current.Char - '0')
-		} else {
-			return 0, errors.New("Missing right argument")
+		rightArg, err := evalInfixOperand(current, "Missing right argument")
+		if err != nil {
+			return 0, err
 		}
-		current.Next()
 		leftArg, err = applyOperator(op, leftArg, rightArg)
 		if err != nil {
 			return 0, err
@@ -232,7 +226,35 @@ func evalInfix(current *Tokenizer) (result int, err error) {
 	return leftArg, nil
 }
 
+// evalInfixOperand is a private function to parse and evaluate an operand in an
+// infix expression: a digit, a parenthesized sub-expression, or a unary minus
+// followed by an operand. If there is no operand, the error has message missing.
+func evalInfixOperand(current *Tokenizer, missing string) (result int, err error) {
+	switch {
+	case current.Char == '-':
+		current.Next()
+		result, err = evalInfixOperand(current, missing)
+		return -result, err
+	case current.Char == '(':
+		current.Next()
+		result, err = evalInfix(current)
+		if err != nil {
+			return 0, err
+		}
+		if current.Char != ')' {
+			return 0, current.Errorf("Missing right parenthesis")
+		}
+	case isDigit(current.Char):
+		result = int(current.Char - '0')
+	default:
+		return 0, current.Errorf("%s", missing)
+	}
+	current.Next()
+	return result, nil
+}
+
 // EvalInfixStack parses and evaluates an infix expression using a stack.
+// Like EvalInfixRecursive, it applies operators from left to right.
 // Pre: Expression in s is well formed
 // Pre violation: return 0 and an error indication
 // Normal return: the expression value and nil
@@ -240,35 +262,52 @@ func evalInfix(current *Tokenizer) (result int, err error) {
 // and check right parens against left parens on the top of the opStack. After pushing
 // a digit or checking a right parens, apply the top operator on the opStack to the top
 // two operands on the valueStack, and push the result on the valueStack, as long as the
-// opStack has an operator on it. The result should be in the valueStack at the end.
+// opStack has an operator on it. A unary minus (a - at the start, after an operator,
+// or after a left paren) is pushed on the opStack as an n, and every n on top of the
+// opStack negates the value on top of the valueStack before the operator is applied.
+// The result should be in the valueStack at the end.
 func EvalInfixStack(s string) (int, error) {
-	current := NewTokenizer(s)
-	opStack := containers.NewLinkedStack()
-	valueStack := containers.NewLinkedStack()
+	current := NewTokenizerSkipSpace(s)
+	opStack := new(stack.LinkedStack)
+	valueStack := new(stack.LinkedStack)
+	unary := true // whether a - here is a unary minus
 	for current.Char != '$' {
-		if isOperator(current.Char) || current.Char == '(' {
+		if current.Char == '-' && unary {
+			opStack.Push(byte('n'))
+		} else if isOperator(current.Char) || current.Char == '(' {
 			opStack.Push(current.Char)
+			unary = true
 		} else {
+			unary = false
 			if isDigit(current.Char) {
 				valueStack.Push(int(current.Char - '0'))
 			} else if current.Char == ')' {
 				if op, err := opStack.Top(); err != nil || op.(byte) != '(' {
-					return 0, errors.New("Missing left parenthesis")
+					return 0, current.Errorf("Missing left parenthesis")
 				}
 				opStack.Pop()
 			} else {
-				return 0, errors.New("Illegal character in expression")
+				return 0, current.Errorf("Illegal character in expression")
 			}
 			op, err := opStack.Top()
+			for err == nil && op.(byte) == 'n' {
+				opStack.Pop()
+				value, popErr := valueStack.Pop()
+				if popErr != nil {
+					return 0, current.Errorf("Missing argument")
+				}
+				valueStack.Push(-value.(int))
+				op, err = opStack.Top()
+			}
 			if err == nil && isOperator(op.(byte)) {
 				opStack.Pop()
 				rightArg, err := valueStack.Pop()
 				if err != nil {
-					return 0, errors.New("Missing right argument")
+					return 0, current.Errorf("Missing right argument")
 				}
 				leftArg, err := valueStack.Pop()
 				if err != nil {
-					return 0, errors.New("Missing left argument")
+					return 0, current.Errorf("Missing left argument")
 				}
 				if value, err := applyOperator(op.(byte), leftArg.(int), rightArg.(int)); err == nil {
 					valueStack.Push(value)
@@ -279,19 +318,174 @@ func EvalInfixStack(s string) (int, error) {
 		}
 		current.Next()
 	}
-	if !opStack.IsEmpty() {
-		return 0, errors.New("Missing argument")
+	if !opStack.Empty() {
+		return 0, current.Errorf("Missing argument")
 	}
 	result, err := valueStack.Pop()
 	if err != nil {
 		return 0, errors.New("Missing expression")
 	}
-	if !valueStack.IsEmpty() {
+	if !valueStack.Empty() {
 		return 0, errors.New("Too many arguments")
 	}
 	return result.(int), nil
 }
 
+// EvalInfixPrecedence parses and evaluates an infix expression using the
+// usual operator precedence: *, /, and % bind tighter than + and -, and
+// operators of equal precedence are applied from left to right, so
+// 5+6*7-2 is 45.
+// Pre: Expression in s is well formed
+// Pre violation: return 0 and an error indication
+// Normal return: the expression value and nil
+// Strategy: This is the shunting-yard algorithm, with operators applied as they
+// come off the opStack rather than being written out. Digits go on the valueStack.
+// Before an operator is pushed on the opStack, every operator on top of the opStack
+// with precedence at least as high is popped and applied to the top two values.
+// Left parens are pushed on the opStack, and a right paren applies operators until
+// the matching left paren is popped. A unary minus is pushed on the opStack as an n
+// that binds tightest of all. At the end, the remaining operators are applied and
+// the result should be alone on the valueStack.
+func EvalInfixPrecedence(s string) (int, error) {
+	return evalInfixPrecedence(s, nil)
+}
+
+// EvalInfixVars parses and evaluates an infix expression just as EvalInfixPrecedence
+// does, except that operands may also be single-letter variables, whose values
+// are looked up in env.
+// Pre: Expression in s is well formed and every variable in it is bound in env
+// Pre violation: return 0 and an error indication
+// Normal return: the expression value and nil
+func EvalInfixVars(s string, env map[byte]int) (int, error) {
+	if env == nil {
+		env = map[byte]int{}
+	}
+	return evalInfixPrecedence(s, env)
+}
+
+// evalInfixPrecedence is a private function that evaluates an infix expression
+// using operator precedence. Letters are variables bound in env, unless env is
+// nil, in which case they are illegal characters.
+func evalInfixPrecedence(s string, env map[byte]int) (int, error) {
+	current := NewTokenizerSkipSpace(s)
+	opStack := new(stack.LinkedStack)
+	valueStack := new(stack.LinkedStack)
+	expectOperand := true // whether the next character must start an operand
+	for current.Char != '$' {
+		isVariable := env != nil && isLetter(current.Char)
+		switch {
+		case isDigit(current.Char) && expectOperand:
+			valueStack.Push(int(current.Char - '0'))
+			expectOperand = false
+		case isVariable && expectOperand:
+			value, ok := env[current.Char]
+			if !ok {
+				return 0, current.Errorf("Unbound variable %c", current.Char)
+			}
+			valueStack.Push(value)
+			expectOperand = false
+		case (current.Char == '(' || current.Char == '-') && expectOperand:
+			if current.Char == '-' {
+				opStack.Push(byte('n'))
+			} else {
+				opStack.Push(current.Char)
+			}
+		case isOperator(current.Char) && !expectOperand:
+			for {
+				op, err := opStack.Top()
+				if err != nil || op.(byte) == '(' || precedence(op.(byte)) < precedence(current.Char) {
+					break
+				}
+				if err := applyTopOperator(opStack, valueStack); err != nil {
+					return 0, err
+				}
+			}
+			opStack.Push(current.Char)
+			expectOperand = true
+		case current.Char == ')' && !expectOperand:
+			for {
+				op, err := opStack.Top()
+				if err != nil {
+					return 0, current.Errorf("Missing left parenthesis")
+				}
+				if op.(byte) == '(' {
+					opStack.Pop()
+					break
+				}
+				if err := applyTopOperator(opStack, valueStack); err != nil {
+					return 0, err
+				}
+			}
+		case isDigit(current.Char), isVariable, current.Char == '(':
+			return 0, current.Errorf("Missing operator")
+		case isOperator(current.Char), current.Char == ')':
+			return 0, current.Errorf("Missing argument")
+		default:
+			return 0, current.Errorf("Illegal character in expression")
+		}
+		current.Next()
+	}
+	if expectOperand {
+		return 0, current.Errorf("Missing argument")
+	}
+	for !opStack.Empty() {
+		if op, _ := opStack.Top(); op.(byte) == '(' {
+			return 0, current.Errorf("Missing right parenthesis")
+		}
+		if err := applyTopOperator(opStack, valueStack); err != nil {
+			return 0, err
+		}
+	}
+	result, err := valueStack.Pop()
+	if err != nil {
+		return 0, errors.New("Missing expression")
+	}
+	if !valueStack.Empty() {
+		return 0, errors.New("Too many arguments")
+	}
+	return result.(int), nil
+}
+
+// precedence returns the binding strength of an operator: unary minus (n)
+// binds tightest, then * / and %, then + and -.
+func precedence(op byte) int {
+	switch op {
+	case '+', '-':
+		return 1
+	case 'n':
+		return 3
+	}
+	return 2
+}
+
+// applyTopOperator pops the operator on top of opStack, applies it to the top
+// two values on valueStack (or just the top one for a unary minus), and pushes
+// the result back on valueStack.
+func applyTopOperator(opStack, valueStack *stack.LinkedStack) error {
+	op, err := opStack.Pop()
+	if err != nil {
+		return errors.New("Missing operator")
+	}
+	rightArg, err := valueStack.Pop()
+	if err != nil {
+		return errors.New("Missing right argument")
+	}
+	if op.(byte) == 'n' {
+		valueStack.Push(-rightArg.(int))
+		return nil
+	}
+	leftArg, err := valueStack.Pop()
+	if err != nil {
+		return errors.New("Missing left argument")
+	}
+	value, err := applyOperator(op.(byte), leftArg.(int), rightArg.(int))
+	if err != nil {
+		return err
+	}
+	valueStack.Push(value)
+	return nil
+}
+
 //////////////////////////////////////////////////////////////////////////
 // Postfix: These functions evaluate a postfix expression held in a string.
 
@@ -301,10 +495,10 @@ func EvalInfixStack(s string) (int, error) {
 // Pre violation: return 0 and an error indication
 // Normal return: the expression value and nil
 func EvalPostfixRecursive(s string) (int, error) {
-	current := NewTokenizer(s)
+	current := NewTokenizerSkipSpace(s)
 	result, err := evalPostfix(current)
 	if err == nil && current.Char != '$' {
-		return 0, errors.New("Extra characters at the end of the expression")
+		return 0, current.Errorf("Extra characters at the end of the expression")
 	}
 	return result, err
 }
@@ -312,25 +506,20 @@ func EvalPostfixRecursive(s string) (int, error) {
 // evalPostfix is a private function that recursively parses and evaluates
 // a postfix expression provided by a Tokenizer.
 // Strategy: The first character must be a digit, so remember it as the leftArg.
-// Assume the next digit is the rightArg. If the character after that is not an
-// operator, then back up one character and call evalPostfix recursively to
-// evaluate the right operand expression. When the operator is finally found,
-// apply it to leftArg and rightArg and leave the result in leftArg. Look for
-// another digit as the start of a possible following expression and repeat.
+// If the next digit is followed by an operator, it is the rightArg; otherwise,
+// call evalPostfix recursively to evaluate the right operand expression that
+// starts with it. When the operator is finally found, apply it to leftArg and
+// rightArg and leave the result in leftArg. Look for another digit as the start
+// of a possible following expression and repeat.
 func evalPostfix(current *Tokenizer) (resul int, err error) {
 	if !isDigit(current.Char) {
-		return 0, errors.New("Missing argument")
+		return 0, current.Errorf("Missing argument")
 	}
 	leftArg := int(current.Char - '0')
 	current.Next()
 	for isDigit(current.Char) {
-		rightArg := int(current.Char - '0')
-		current.Next()
-		if current.Char == '$' {
-			return 0, errors.New("Missing operator")
-		}
-		if isDigit(current.Char) {
-			current.Last()
+		var rightArg int
+		if isDigit(current.Peek()) {
 			for {
 				rightArg, err = evalPostfix(current)
 				if err != nil {
@@ -340,6 +529,12 @@ func evalPostfix(current *Tokenizer) (resul int, err error) {
 					break
 				}
 			}
+		} else {
+			rightArg = int(current.Char - '0')
+			current.Next()
+		}
+		if current.Char == '$' {
+			return 0, current.Errorf("Missing operator")
 		}
 		leftArg, err = applyOperator(current.Char, leftArg, rightArg)
 		if err != nil {
@@ -358,19 +553,19 @@ func evalPostfix(current *Tokenizer) (resul int, err error) {
 // encountered, apply it to the top two values in the stack and push the
 // result back on the stack. At the end, the stack should contain the result.
 func EvalPostfixStack(s string) (int, error) {
-	current := NewTokenizer(s)
-	stack := containers.NewLinkedStack()
+	current := NewTokenizerSkipSpace(s)
+	stack := new(stack.LinkedStack)
 	for current.Char != '$' {
 		if isDigit(current.Char) {
 			stack.Push(int(current.Char - '0'))
 		} else {
 			rightArg, err := stack.Pop()
 			if err != nil {
-				return 0, errors.New("Missing right argument")
+				return 0, current.Errorf("Missing right argument")
 			}
 			leftArg, err := stack.Pop()
 			if err != nil {
-				return 0, errors.New("Missing left argument")
+				return 0, current.Errorf("Missing left argument")
 			}
 			value, err := applyOperator(current.Char, leftArg.(int), rightArg.(int))
 			if err == nil {
@@ -385,7 +580,47 @@ func EvalPostfixStack(s string) (int, error) {
 	if err != nil {
 		return 0, errors.New("Missing expression")
 	}
-	if !stack.IsEmpty() {
+	if !stack.Empty() {
+		return 0, errors.New("Too many arguments")
+	}
+	return result.(int), nil
+}
+
+// EvalRPN uses a stack to evaluate a postfix (reverse Polish) expression that
+// has already been split into tokens. Unlike in EvalPostfixStack, operands may
+// be any ints, including negative ones such as "-12", and each operator token
+// is one of +, -, *, /, and %.
+// Pre: The expression in tokens is well formed
+// Pre violation: return 0 and an error indication
+// Normal return: the expression value and nil
+func EvalRPN(tokens []string) (int, error) {
+	stack := new(stack.LinkedStack)
+	for _, token := range tokens {
+		if len(token) == 1 && isOperator(token[0]) {
+			rightArg, err := stack.Pop()
+			if err != nil {
+				return 0, errors.New("Missing right argument")
+			}
+			leftArg, err := stack.Pop()
+			if err != nil {
+				return 0, errors.New("Missing left argument")
+			}
+			value, err := applyOperator(token[0], leftArg.(int), rightArg.(int))
+			if err != nil {
+				return 0, err
+			}
+			stack.Push(value)
+		} else if value, err := strconv.Atoi(token); err == nil {
+			stack.Push(value)
+		} else {
+			return 0, fmt.Errorf("Bad token %q", token)
+		}
+	}
+	result, err := stack.Pop()
+	if err != nil {
+		return 0, errors.New("Missing expression")
+	}
+	if !stack.Empty() {
 		return 0, errors.New("Too many arguments")
 	}
 	return result.(int), nil
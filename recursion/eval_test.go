@@ -1,7 +1,10 @@
 package recursion
 
-//import "fmt"
-import "testing"
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
 
 func TestPrefixEval(t *testing.T) {
 	testPrefixEvalFunction(t, EvalPrefixRecursive, "prefix recursive")
@@ -41,7 +44,7 @@ func testPrefixEvalFunction(t *testing.T, eval func(string) (int, error), name s
 	if val, err := eval("+-8*72%-643"); err != nil {
 		t.Errorf("%v fails: %v", name, err)
 	} else if val != -4 {
-		t.Errorf("%v fails on +-8*72%-643 with value %v", name, val)
+		t.Errorf("%v fails on +-8*72%%-643 with value %v", name, val)
 	}
 }
 
@@ -88,6 +91,275 @@ func testInfixEvalFunction(t *testing.T, eval func(string) (int, error), name st
 	}
 }
 
+func TestInfixPrecedenceEval(t *testing.T) {
+	name := "infix precedence"
+	if val, err := EvalInfixPrecedence(""); err == nil {
+		t.Errorf("%v fails on empty string with value %v", name, val)
+	}
+	for _, s := range []string{"56", "+56", "(56)", "(5+6", "5+6)", "5+", "5+*6", "()", "5(6)", "5 6"} {
+		if val, err := EvalInfixPrecedence(s); err == nil {
+			t.Errorf("%v fails on %v with value %v", name, s, val)
+		}
+	}
+	expected := []struct {
+		expr  string
+		value int
+	}{
+		{"5", 5}, {"5+6", 11}, {"(5+6)*(7-2)", 55}, {"5+6*7-2", 45},
+		{"9-4-3", 2}, {"8/4/2", 1}, {"2+9%4*3", 5}, {"((2))", 2},
+		{"2*(3+4)*5", 70}, {"9-(4-3)", 8}, {"1+2*3-8/4%3", 5},
+	}
+	for _, e := range expected {
+		if val, err := EvalInfixPrecedence(e.expr); err != nil {
+			t.Errorf("%v fails on %v: %v", name, e.expr, err)
+		} else if val != e.value {
+			t.Errorf("%v fails on %v with value %v; expected %v", name, e.expr, val, e.value)
+		}
+	}
+}
+
+func TestInfixVarsEval(t *testing.T) {
+	env := map[byte]int{'x': 3, 'y': 4, 'Z': -2}
+	expected := []struct {
+		expr  string
+		value int
+	}{
+		{"x", 3}, {"x*x+y*y", 25}, {"x * x + y * y", 25}, {"(x+y)*Z", -14},
+		{"-x+2*y", 5}, {"y%x-Z", 3}, {"x-y-1", -2},
+	}
+	for _, e := range expected {
+		if val, err := EvalInfixVars(e.expr, env); err != nil {
+			t.Errorf("infix vars fails on %v: %v", e.expr, err)
+		} else if val != e.value {
+			t.Errorf("infix vars fails on %v with value %v; expected %v", e.expr, val, e.value)
+		}
+	}
+	for _, s := range []string{"x+w", "xy", "x2", "2x", "x(y)", "x+", "x/(y-4)", "z"} {
+		if val, err := EvalInfixVars(s, env); err == nil {
+			t.Errorf("infix vars fails on %v with value %v", s, val)
+		}
+	}
+	if val, err := EvalInfixVars("x", nil); err == nil {
+		t.Errorf("infix vars fails on x with no bindings with value %v", val)
+	}
+	if val, err := EvalInfixVars("5+6*7-2", nil); err != nil || val != 45 {
+		t.Errorf("infix vars fails on 5+6*7-2 with no bindings with value %v and error %v", val, err)
+	}
+	if val, err := EvalInfixPrecedence("x"); err == nil {
+		t.Errorf("infix precedence fails on x with value %v", val)
+	}
+}
+
+func TestDivisionByZero(t *testing.T) {
+	expressions := []struct {
+		eval func(string) (int, error)
+		name string
+		expr []string
+	}{
+		{EvalPrefixRecursive, "prefix recursive", []string{"/60", "%60", "+5/6-22"}},
+		{EvalPrefixStack, "prefix stack", []string{"/60", "%60", "+5/6-22"}},
+		{EvalInfixRecursive, "infix recursive", []string{"6/0", "6%0", "5+6/(2-2)"}},
+		{EvalInfixStack, "infix stack", []string{"6/0", "6%0", "5+6/(2-2)"}},
+		{EvalInfixPrecedence, "infix precedence", []string{"6/0", "6%0", "5+6/(2-2)"}},
+		{EvalPostfixRecursive, "postfix recursive", []string{"60/", "60%", "5622-/+"}},
+		{EvalPostfixStack, "postfix stack", []string{"60/", "60%", "5622-/+"}},
+	}
+	for _, e := range expressions {
+		for _, s := range e.expr {
+			if val, err := e.eval(s); err == nil {
+				t.Errorf("%v fails on %v with value %v", e.name, s, val)
+			}
+		}
+	}
+}
+
+func TestWhitespace(t *testing.T) {
+	expressions := []struct {
+		eval func(string) (int, error)
+		name string
+		expr []string
+	}{
+		{EvalPrefixRecursive, "prefix recursive", []string{"- * 3 5 4", "  -*35 4", "-*354\t ", " \t-  * 3\t5 4 "}},
+		{EvalPrefixStack, "prefix stack", []string{"- * 3 5 4", "  -*35 4", "-*354\t ", " \t-  * 3\t5 4 "}},
+		{EvalInfixRecursive, "infix recursive", []string{"3 * 5 - 4", "  3*5-4", "3*5-4\t ", " ( 3*5 )\t- 4 "}},
+		{EvalInfixStack, "infix stack", []string{"3 * 5 - 4", "  3*5-4", "3*5-4\t ", " ( 3*5 )\t- 4 "}},
+		{EvalInfixPrecedence, "infix precedence", []string{"3 * 5 - 4", "  3*5-4", "3*5-4\t ", " ( 3*5 )\t- 4 "}},
+		{EvalPostfixRecursive, "postfix recursive", []string{"3 5 * 4 -", "  35*4-", "35*4-\t ", " 3\t5  *4 - "}},
+		{EvalPostfixStack, "postfix stack", []string{"3 5 * 4 -", "  35*4-", "35*4-\t ", " 3\t5  *4 - "}},
+	}
+	for _, e := range expressions {
+		for _, s := range e.expr {
+			if val, err := e.eval(s); err != nil {
+				t.Errorf("%v fails on %q: %v", e.name, s, err)
+			} else if val != 11 {
+				t.Errorf("%v fails on %q with value %v", e.name, s, val)
+			}
+		}
+		if val, err := e.eval(" \t "); err == nil {
+			t.Errorf("%v fails on blank string with value %v", e.name, val)
+		}
+	}
+	if val, err := EvalInfixStack("5 + 6 * 7"); err != nil || val != 77 {
+		t.Errorf("infix stack fails on 5 + 6 * 7 with value %v and error %v", val, err)
+	}
+	if val, err := EvalPostfixRecursive("3 4 5 + *"); err != nil || val != 27 {
+		t.Errorf("postfix recursive fails on 3 4 5 + * with value %v and error %v", val, err)
+	}
+
+	// the default Tokenizer still reports spaces
+	current := NewTokenizer(" 5")
+	if current.Char != ' ' {
+		t.Errorf("Tokenizer should not skip spaces but has char %q", current.Char)
+	}
+	current = NewTokenizerSkipSpace(" 5 \t6 ")
+	if current.Char != '5' {
+		t.Errorf("Tokenizer should skip leading spaces but has char %q", current.Char)
+	}
+	current.Next()
+	current.Last()
+	if current.Char != '5' {
+		t.Errorf("Tokenizer Last should back up over spaces but has char %q", current.Char)
+	}
+	current.Next()
+	if current.Char != '6' {
+		t.Errorf("Tokenizer should skip interior spaces but has char %q", current.Char)
+	}
+	if current.Next(); current.Char != '$' {
+		t.Errorf("Tokenizer should skip trailing spaces but has char %q", current.Char)
+	}
+}
+
+func TestUnaryMinus(t *testing.T) {
+	evals := []struct {
+		eval func(string) (int, error)
+		name string
+	}{
+		{EvalInfixRecursive, "infix recursive"},
+		{EvalInfixStack, "infix stack"},
+		{EvalInfixPrecedence, "infix precedence"},
+	}
+	expected := []struct {
+		expr  string
+		value int
+	}{
+		{"-5", -5}, {"-5+2", -3}, {"3*-2", -6}, {"5--2", 7}, {"--4", 4},
+		{"-(2+3)", -5}, {"(-2)*(-3)", 6}, {"7/-2", -3}, {"- 5 - -(1 - 3)", -7},
+	}
+	for _, e := range evals {
+		for _, x := range expected {
+			if val, err := e.eval(x.expr); err != nil {
+				t.Errorf("%v fails on %v: %v", e.name, x.expr, err)
+			} else if val != x.value {
+				t.Errorf("%v fails on %v with value %v; expected %v", e.name, x.expr, val, x.value)
+			}
+		}
+		for _, s := range []string{"-", "5-", "5*-", "-()", "-+5", "5-*2", "(-)"} {
+			if val, err := e.eval(s); err == nil {
+				t.Errorf("%v fails on %v with value %v", e.name, s, val)
+			}
+		}
+	}
+
+	// precedence still applies around a unary minus
+	if val, err := EvalInfixPrecedence("-2+3*-4"); err != nil || val != -14 {
+		t.Errorf("infix precedence fails on -2+3*-4 with value %v and error %v", val, err)
+	}
+	if val, err := EvalInfixRecursive("-2+3*-4"); err != nil || val != -4 {
+		t.Errorf("infix recursive fails on -2+3*-4 with value %v and error %v", val, err)
+	}
+}
+
+func TestTokenizerPeek(t *testing.T) {
+	for _, newTokenizer := range []func(string) *Tokenizer{NewTokenizer, NewTokenizerSkipSpace} {
+		for _, s := range []string{"", "5", "5+6", "(5 + 6)*\t7 ", " 1 2  3"} {
+			current, next := newTokenizer(s), newTokenizer(s)
+			next.Next()
+			for current.Char != '$' {
+				ch := current.Char
+				if peek := current.Peek(); peek != next.Char {
+					t.Errorf("Peek in %q should return %q but returns %q", s, next.Char, peek)
+				}
+				if current.Peek(); current.Char != ch {
+					t.Errorf("Peek in %q should not change the char %q but changes it to %q", s, ch, current.Char)
+				}
+				current.Next()
+				if current.Char != next.Char {
+					t.Errorf("Next in %q after Peek should give %q but gives %q", s, next.Char, current.Char)
+				}
+				next.Next()
+			}
+			if peek := current.Peek(); peek != '$' {
+				t.Errorf("Peek at the end of %q should return $ but returns %q", s, peek)
+			}
+		}
+	}
+
+	// Peek does not upset Last
+	current := NewTokenizerSkipSpace("1 2 3")
+	current.Next()
+	current.Peek()
+	current.Last()
+	if current.Char != '1' {
+		t.Errorf("Last after Peek should give 1 but gives %q", current.Char)
+	}
+}
+
+func TestTokenizerPosition(t *testing.T) {
+	current := NewTokenizerSkipSpace(" 5 +\t6")
+	for _, expected := range []struct {
+		ch       byte
+		position int
+	}{{'5', 1}, {'+', 3}, {'6', 5}, {'$', 6}} {
+		if current.Char != expected.ch || current.Position() != expected.position {
+			t.Errorf("Tokenizer should have %q at %v but has %q at %v",
+				expected.ch, expected.position, current.Char, current.Position())
+		}
+		current.Next()
+	}
+	current = NewTokenizerSkipSpace("1 2 3")
+	current.Next()
+	current.Next()
+	current.Last()
+	if current.Char != '2' || current.Position() != 2 {
+		t.Errorf("Tokenizer Last should give 2 at 2 but gives %q at %v", current.Char, current.Position())
+	}
+}
+
+func TestErrorPosition(t *testing.T) {
+	data := []struct {
+		eval     func(string) (int, error)
+		name, s  string
+		position int
+	}{{EvalInfixStack, "infix stack", "5+6#7", 3},
+		{EvalInfixStack, "infix stack", "(5+6))", 5},
+		{EvalInfixPrecedence, "infix precedence", "5 + 6 7", 6},
+		{EvalInfixPrecedence, "infix precedence", "5 + (6 * 7", 10},
+		{EvalInfixRecursive, "infix recursive", "5+(6*7", 6},
+		{EvalPrefixRecursive, "prefix recursive", "+56 7", 4},
+		{EvalPrefixStack, "prefix stack", "+5?6", 2},
+		{EvalPostfixRecursive, "postfix recursive", "56+7", 4},
+		{EvalPostfixStack, "postfix stack", "5+6", 1},
+		{func(s string) (int, error) { return EvalInfixVars(s, map[byte]int{'x': 1}) },
+			"infix vars", "x+y", 2}}
+	for _, d := range data {
+		_, err := d.eval(d.s)
+		if err == nil {
+			t.Errorf("%v should fail on %q", d.name, d.s)
+			continue
+		}
+		snippet := fmt.Sprintf("at position %d\n%s\n%s^", d.position, d.s, strings.Repeat(" ", d.position))
+		if !strings.HasSuffix(err.Error(), snippet) {
+			t.Errorf("%v error on %q should end with %q but is %q", d.name, d.s, snippet, err.Error())
+		}
+	}
+	if _, err := Infix2Postfix("5+6#7"); err == nil || !strings.Contains(err.Error(), "at position 3") {
+		t.Errorf("Infix2Postfix error on 5+6#7 should be at position 3 but is %v", err)
+	}
+	if _, err := ParseInfix("5*\t(6"); err == nil || !strings.HasSuffix(err.Error(), "5*\t(6\n  \t  ^") {
+		t.Errorf("ParseInfix error on 5*\\t(6 should keep the tab in the caret line but is %q", err)
+	}
+}
+
 func TestPostfixEval(t *testing.T) {
 	testPostfixEvalFunction(t, EvalPostfixRecursive, "postfix recursive")
 	testPostfixEvalFunction(t, EvalPostfixStack, "postfix stack")
@@ -130,8 +402,38 @@ func testPostfixEvalFunction(t *testing.T, eval func(string) (int, error), name
 		t.Errorf("%v fails on 56*71-3+* with value %v", name, val)
 	}
 	if val, err := eval("12+31/43%+42**+"); err != nil {
-		t.Errorf("%v fails on 12+31/43%+42**+: %v", name, err)
+		t.Errorf("%v fails on 12+31/43%%+42**+: %v", name, err)
 	} else if val != 35 {
-		t.Errorf("%v fails on 12+31/43%+42**+ with value %v", name, val)
+		t.Errorf("%v fails on 12+31/43%%+42**+ with value %v", name, val)
+	}
+}
+
+func TestEvalRPN(t *testing.T) {
+	expected := []struct {
+		tokens []string
+		value  int
+	}{
+		{[]string{"42"}, 42},
+		{[]string{"-7"}, -7},
+		{[]string{"3", "4", "+", "2", "*"}, 14},
+		{[]string{"100", "-25", "-", "7", "/"}, 17},
+		{[]string{"2", "1", "+", "3", "*", "12", "5", "%", "-"}, 7},
+		{[]string{"15", "7", "1", "1", "+", "-", "/", "3", "*", "2", "1", "1", "+", "+", "-"}, 5},
+	}
+	for _, e := range expected {
+		if val, err := EvalRPN(e.tokens); err != nil {
+			t.Errorf("EvalRPN fails on %v: %v", e.tokens, err)
+		} else if val != e.value {
+			t.Errorf("EvalRPN fails on %v with value %v; expected %v", e.tokens, val, e.value)
+		}
+	}
+	malformed := [][]string{
+		nil, {}, {"+"}, {"1", "+"}, {"1", "2"}, {"1", "2", "+", "+"},
+		{"1", "x", "+"}, {"1", "2", "^"}, {"1", "2", "++"}, {"1", ""}, {"4", "0", "/"},
+	}
+	for _, tokens := range malformed {
+		if val, err := EvalRPN(tokens); err == nil {
+			t.Errorf("EvalRPN fails on %q with value %v", tokens, val)
+		}
 	}
 }
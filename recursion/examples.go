@@ -5,7 +5,7 @@
 package recursion
 
 import (
-	"containers"
+	"containers/stack"
 	"fmt"
 	"unicode/utf8"
 )
@@ -15,7 +15,7 @@ import (
 
 // Reverse returns the reverse of its string argument using a stack.
 func Reverse(s string) string {
-	stack := containers.NewLinkedStack()
+	stack := new(stack.LinkedStack)
 	for _, ch := range s {
 		stack.Push(ch)
 	}
@@ -42,6 +42,7 @@ type HanoiState struct {
 	towerA    []byte
 	towerB    []byte
 	towerC    []byte
+	moves     [][2]int // every (src, dst) move made so far
 }
 
 const ( // the towers
@@ -97,6 +98,7 @@ func (s *HanoiState) MoveDisk(src, dst int) {
 		s.towerC = s.towerC[:len(s.towerC)-1]
 	}
 	s.moveCount++
+	s.moves = append(s.moves, [2]int{src, dst})
 	//fmt.Printf("%s\n",s)			// uncomment this line to see all the moves
 }
 
@@ -112,6 +114,19 @@ func (s *HanoiState) MoveTower(src, dst, aux, n int) {
 	}
 }
 
+// MoveTowerRecording transfers n disks from src tower to dst tower just as
+// MoveTower does, and returns the (src, dst) moves it made, in order.
+func (s *HanoiState) MoveTowerRecording(src, dst, aux, n int) [][2]int {
+	start := len(s.moves)
+	s.MoveTower(src, dst, aux, n)
+	return append([][2]int(nil), s.moves[start:]...)
+}
+
+// Moves returns the (src, dst) moves made so far, in order.
+func (s *HanoiState) Moves() [][2]int {
+	return append([][2]int(nil), s.moves...)
+}
+
 // moveTask stores tower move tasks pushed on a stack in the stack-based
 // solution to the Towers of Hanoi problem.
 type moveTask struct {
@@ -128,7 +143,7 @@ func newMoveTask(src, dst, aux, n int) *moveTask {
 // MoveTowerStack solves the Towers of Hanoi problem using a stack rather
 // than recursion.
 func (s *HanoiState) MoveTowerStack(src, dst, aux, n int) {
-	stack := containers.NewLinkedStack()
+	stack := new(stack.LinkedStack)
 	task := newMoveTask(src, dst, aux, n)
 	stack.Push(task)
 	for val, err := stack.Pop(); err == nil; val, err = stack.Pop() {
@@ -184,13 +199,13 @@ func isBalancedBrackets(current *Tokenizer) bool {
 // This function uses a stack to parse a string of brackets.
 func IsBalancedStack(s string) bool {
 	current := NewTokenizer(s)
-	stack := containers.NewLinkedStack()
+	stack := new(stack.LinkedStack)
 	for current.Char != '$' {
 		switch current.Char {
 		case '[':
 			stack.Push(current.Char)
 		case ']':
-			if stack.IsEmpty() {
+			if stack.Empty() {
 				return false
 			}
 			stack.Pop()
@@ -199,7 +214,7 @@ func IsBalancedStack(s string) bool {
 		}
 		current.Next()
 	}
-	return stack.IsEmpty()
+	return stack.Empty()
 }
 
 //////////////////////////////////////////////////////////////////////
@@ -253,3 +268,129 @@ func Search(slice []int, value int) bool {
 	}
 	return false
 }
+
+//////////////////////////////////////////////////////////////////////////////
+// N-Queens--recursive backtracking.
+
+// NQueens returns every way to place n queens on an n by n board so that no
+// two attack each other. Each solution is a slice whose ith element is the
+// column of the queen in row i. Queens are placed one row at a time, and a
+// placement is abandoned as soon as it conflicts with an earlier row.
+func NQueens(n int) [][]int {
+	var result [][]int
+	columns := make([]int, n)
+	var place func(row int)
+	place = func(row int) {
+		if row == n {
+			solution := make([]int, n)
+			copy(solution, columns)
+			result = append(result, solution)
+			return
+		}
+		for col := 0; col < n; col++ {
+			if isSafe(columns[:row], col) {
+				columns[row] = col
+				place(row + 1)
+			}
+		}
+	}
+	if 0 < n {
+		place(0)
+	}
+	return result
+}
+
+// isSafe tests whether a queen may go in column col of the row after those
+// whose queens' columns are given in placed, which happens when no earlier
+// queen shares its column or either of its diagonals.
+func isSafe(placed []int, col int) bool {
+	row := len(placed)
+	for r, c := range placed {
+		if c == col || c-col == row-r || col-c == row-r {
+			return false
+		}
+	}
+	return true
+}
+
+//////////////////////////////////////////////////////////////////////////////
+// Gray codes
+
+// GrayCode returns the n-bit reflected binary Gray code, a sequence of all
+// 2^n n-bit values in which each value differs from the next, and the last
+// from the first, in exactly one bit. The n-bit code is the (n-1)-bit code
+// followed by the (n-1)-bit code reversed with its high bit set.
+func GrayCode(n int) []int {
+	if n <= 0 {
+		return []int{0}
+	}
+	previous := GrayCode(n - 1)
+	result := make([]int, 0, 2*len(previous))
+	result = append(result, previous...)
+	highBit := 1 << uint(n-1)
+	for i := len(previous) - 1; 0 <= i; i-- {
+		result = append(result, previous[i]|highBit)
+	}
+	return result
+}
+
+//////////////////////////////////////////////////////////////////////////////
+// Binomial coefficients--Pascal's triangle.
+
+// Binomial computes C(n,k), the number of ways to choose k things from n, by
+// building row n of Pascal's triangle in place, so that no intermediate value
+// exceeds C(n,k) as n!/(k!(n-k)!) would. Only the first k+1 entries of each
+// row are needed, and using symmetry to keep k <= n/2 halves the work.
+func Binomial(n, k int) int {
+	if k < 0 || n < k {
+		return 0
+	}
+	if n-k < k {
+		k = n - k
+	}
+	row := make([]int, k+1)
+	row[0] = 1
+	for i := 1; i <= n; i++ {
+		for j := min(i, k); 0 < j; j-- {
+			row[j] += row[j-1]
+		}
+	}
+	return row[k]
+}
+
+// min returns the smaller of a and b.
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+//////////////////////////////////////////////////////////////////////////////
+// Flattening nested slices
+
+// FlattenNested returns the ints in data in left-to-right order, where data
+// is an int or a []interface{} whose elements are again ints or such slices,
+// nested to any depth. Slices are flattened by recursing into each element.
+// Pre: data and the elements of all its slices are ints or []interface{}s
+// Pre violation: panic
+// Normal return: the ints in data, in order
+func FlattenNested(data interface{}) []int {
+	result := []int{}
+	flattenNested(data, &result)
+	return result
+}
+
+// flattenNested appends the ints in data to *result.
+func flattenNested(data interface{}, result *[]int) {
+	switch v := data.(type) {
+	case int:
+		*result = append(*result, v)
+	case []interface{}:
+		for _, e := range v {
+			flattenNested(e, result)
+		}
+	default:
+		panic(fmt.Sprintf("FlattenNested: %v is not an int or a []interface{}", data))
+	}
+}
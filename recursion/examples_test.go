@@ -1,6 +1,5 @@
 package recursion
 
-import "fmt"
 import "testing"
 
 func TestReverse(t *testing.T) {
@@ -19,7 +18,7 @@ func TestHanoi(t *testing.T) {
 	s = NewHanoiState(8)
 	s.MoveTower(A, C, B, 8)
 	if s.moveCount != 255 {
-		t.Errorf(fmt.Sprintf("Recursive Hanoi is broken"))
+		t.Error("Recursive Hanoi is broken")
 	}
 }
 
@@ -28,43 +27,88 @@ func TestHanoiStack(t *testing.T) {
 	s = NewHanoiState(8)
 	s.MoveTowerStack(A, C, B, 8)
 	if s.moveCount != 255 {
-		t.Errorf(fmt.Sprintf("Stack-based Hanoi is broken"))
+		t.Error("Stack-based Hanoi is broken")
 	}
 }
 
-func testBalancedBracketsFunction(t *testing.T, isBalanced func(string) bool) {
+func TestHanoiRecording(t *testing.T) {
+	for n := 1; n <= 8; n++ {
+		s := NewHanoiState(byte(n))
+		moves := s.MoveTowerRecording(A, C, B, n)
+		if len(moves) != 1<<uint(n)-1 {
+			t.Errorf("Hanoi with %v disks should record %v moves but records %v", n, 1<<uint(n)-1, len(moves))
+		}
+
+		// replaying the moves on a fresh state solves the puzzle
+		goal := NewHanoiState(byte(n))
+		replay := NewHanoiState(byte(n))
+		for _, m := range moves {
+			if len(replay.towerA)+len(replay.towerB)+len(replay.towerC) != n {
+				t.Fatalf("Hanoi with %v disks lost a disk replaying %v", n, m)
+			}
+			replay.MoveDisk(m[0], m[1])
+		}
+		if len(replay.towerA) != 0 || len(replay.towerB) != 0 || string(replay.towerC) != string(goal.towerA) {
+			t.Errorf("Hanoi with %v disks should reach the goal by replaying its moves but reaches\n%v", n, replay)
+		}
+
+		// the stack-based solution makes the same moves
+		stackState := NewHanoiState(byte(n))
+		stackState.MoveTowerStack(A, C, B, n)
+		stackMoves := stackState.Moves()
+		if len(stackMoves) != len(moves) {
+			t.Errorf("Stack-based Hanoi with %v disks should record %v moves but records %v", n, len(moves), len(stackMoves))
+			continue
+		}
+		for i := range moves {
+			if moves[i] != stackMoves[i] {
+				t.Errorf("Stack-based Hanoi with %v disks makes move %v instead of %v", n, stackMoves[i], moves[i])
+				break
+			}
+		}
+	}
+
+	// a recording only covers its own moves
+	s := NewHanoiState(3)
+	s.MoveTower(A, B, C, 3)
+	if moves := s.MoveTowerRecording(B, C, A, 3); len(moves) != 7 || len(s.Moves()) != 14 {
+		t.Errorf("Hanoi should record 7 of 14 moves but records %v of %v", len(moves), len(s.Moves()))
+	}
+}
+
+func testBalancedBracketsFunction(t *testing.T, name string, isBalanced func(string) bool) {
 	if !isBalanced("") {
-		t.Errorf("%v fails on empty string", isBalanced)
+		t.Errorf("%v fails on empty string", name)
 	}
 	if !isBalanced("[]") {
-		t.Errorf("%vfails on []", isBalanced)
+		t.Errorf("%v fails on []", name)
 	}
 	if !isBalanced("[][]") {
-		t.Errorf("%v fails on [][]", isBalanced)
+		t.Errorf("%v fails on [][]", name)
 	}
 	if !isBalanced("[][][]") {
-		t.Errorf("%v fails on [][][]", isBalanced)
+		t.Errorf("%v fails on [][][]", name)
 	}
 	if !isBalanced("[[]]") {
-		t.Errorf("%v fails on [[]]", isBalanced)
+		t.Errorf("%v fails on [[]]", name)
 	}
 	if !isBalanced("[[[]][[][]]]") {
-		t.Errorf("%v fails on [[[]][[][]]]", isBalanced)
+		t.Errorf("%v fails on [[[]][[][]]]", name)
 	}
 	if isBalanced("[") {
-		t.Errorf("%v fails on [", isBalanced)
+		t.Errorf("%v fails on [", name)
 	}
 	if isBalanced("]") {
-		t.Errorf("%v fails on ]", isBalanced)
+		t.Errorf("%v fails on ]", name)
 	}
 	if isBalanced("[[]") {
-		t.Errorf("%v fails on [[]", isBalanced)
+		t.Errorf("%v fails on [[]", name)
 	}
 	if isBalanced("[[]") {
-		t.Errorf("%v fails on []]", isBalanced)
+		t.Errorf("%v fails on []]", name)
 	}
 	if isBalanced("[[[[][[]]]]") {
-		t.Errorf("%v fails on [[[][[]]]]", isBalanced)
+		t.Errorf("%v fails on [[[][[]]]]", name)
 	}
 }
 
@@ -84,8 +128,8 @@ func TestFactorail(t *testing.T) {
 }
 
 func TestBalancedBrackets(t *testing.T) {
-	testBalancedBracketsFunction(t, IsBalancedRecursive)
-	testBalancedBracketsFunction(t, IsBalancedStack)
+	testBalancedBracketsFunction(t, "IsBalancedRecursive", IsBalancedRecursive)
+	testBalancedBracketsFunction(t, "IsBalancedStack", IsBalancedStack)
 }
 
 func TestRecursiveSearch(t *testing.T) {
@@ -137,3 +181,129 @@ func TestRecursiveSearch(t *testing.T) {
 		t.Errorf("Recursive search did not find 1998")
 	}
 }
+
+func TestNQueens(t *testing.T) {
+	counts := []int{0, 1, 0, 0, 2, 10, 4, 40, 92}
+	for n, count := range counts {
+		solutions := NQueens(n)
+		if len(solutions) != count {
+			t.Errorf("NQueens(%v) should have %v solutions but has %v", n, count, len(solutions))
+		}
+		seen := make(map[string]bool)
+		for _, board := range solutions {
+			if len(board) != n {
+				t.Errorf("NQueens(%v) board %v has the wrong size", n, board)
+				continue
+			}
+			for i := 0; i < n; i++ {
+				for j := i + 1; j < n; j++ {
+					if board[i] == board[j] || board[i]-board[j] == j-i || board[j]-board[i] == j-i {
+						t.Errorf("NQueens(%v) board %v has queens in rows %v and %v attacking", n, board, i, j)
+					}
+				}
+			}
+			key := ""
+			for _, c := range board {
+				key += string(rune('a' + c))
+			}
+			if seen[key] {
+				t.Errorf("NQueens(%v) board %v appears more than once", n, board)
+			}
+			seen[key] = true
+		}
+	}
+	if board := NQueens(4)[0]; board[0] != 1 || board[1] != 3 || board[2] != 0 || board[3] != 2 {
+		t.Errorf("NQueens(4) first solution should be [1 3 0 2] but is %v", board)
+	}
+}
+
+func TestGrayCode(t *testing.T) {
+	if code := GrayCode(0); len(code) != 1 || code[0] != 0 {
+		t.Errorf("GrayCode(0) should be [0] but is %v", code)
+	}
+	if code := GrayCode(3); !sameInts(code, []int{0, 1, 3, 2, 6, 7, 5, 4}) {
+		t.Errorf("GrayCode(3) should be [0 1 3 2 6 7 5 4] but is %v", code)
+	}
+	for n := 1; n <= 10; n++ {
+		code := GrayCode(n)
+		if len(code) != 1<<uint(n) {
+			t.Errorf("GrayCode(%v) should have %v values but has %v", n, 1<<uint(n), len(code))
+			continue
+		}
+		seen := make([]bool, len(code))
+		for i, v := range code {
+			if v < 0 || len(code) <= v || seen[v] {
+				t.Errorf("GrayCode(%v) has a repeated or out of range value %v", n, v)
+				break
+			}
+			seen[v] = true
+			if diff := v ^ code[(i+1)%len(code)]; diff == 0 || diff&(diff-1) != 0 {
+				t.Errorf("GrayCode(%v) values %v and %v differ in more than one bit", n, v, code[(i+1)%len(code)])
+			}
+		}
+	}
+}
+
+func sameInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestBinomial(t *testing.T) {
+	data := [][3]int{{0, 0, 1}, {5, 0, 1}, {5, 5, 1}, {5, 2, 10}, {6, 3, 20}, {10, 4, 210},
+		{20, 10, 184756}, {52, 5, 2598960}, {5, -1, 0}, {5, 6, 0}, {0, 1, 0}, {60, 30, 118264581564861424}}
+	for _, d := range data {
+		if c := Binomial(d[0], d[1]); c != d[2] {
+			t.Errorf("Binomial(%v,%v) should be %v but is %v", d[0], d[1], d[2], c)
+		}
+	}
+
+	// C(n,k) is the number of n-bit masks with k bits set
+	for n := 0; n <= 12; n++ {
+		counts := make([]int, n+1)
+		for mask := 0; mask < 1<<uint(n); mask++ {
+			bits := 0
+			for m := mask; m != 0; m &= m - 1 {
+				bits++
+			}
+			counts[bits]++
+		}
+		for k, count := range counts {
+			if c := Binomial(n, k); c != count {
+				t.Errorf("Binomial(%v,%v) should be %v but is %v", n, k, count, c)
+			}
+		}
+	}
+}
+
+func TestFlattenNested(t *testing.T) {
+	expected := []struct {
+		data   interface{}
+		result []int
+	}{
+		{7, []int{7}},
+		{[]interface{}{}, []int{}},
+		{[]interface{}{[]interface{}{}, []interface{}{[]interface{}{}}}, []int{}},
+		{[]interface{}{1, []interface{}{2, 3}, []interface{}{[]interface{}{4}, 5}}, []int{1, 2, 3, 4, 5}},
+		{[]interface{}{[]interface{}{[]interface{}{[]interface{}{-1}}}, 0, []interface{}{2}}, []int{-1, 0, 2}},
+	}
+	for _, e := range expected {
+		if result := FlattenNested(e.data); !sameInts(result, e.result) {
+			t.Errorf("FlattenNested(%v) should be %v but is %v", e.data, e.result, result)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("FlattenNested should panic on a string")
+		}
+	}()
+	FlattenNested([]interface{}{1, "two"})
+}
@@ -0,0 +1,269 @@
+// parse.go: This file contains recursive and stack-based algorithms for parsing simple
+// prefix, infix, and postfix expressions held in strings into expression trees. The
+// expressions are as in eval.go: the operators +, -, *, /, and %, one-digit operands,
+// and, in infix expressions, parentheses and unary minus. Spaces and tabs are ignored.
+
+package recursion
+
+import (
+	"containers/stack"
+	"errors"
+	"strconv"
+)
+
+////////////////////////////////////////////////////////////////////////////
+// Expression trees.
+
+// ExprNode is a node in an expression tree. A leaf holds an operand in Value and
+// has Op == 0. An internal node holds an operator in Op applied to its Left and
+// Right subtrees, except that a unary minus is an Op of '-' with a nil Left.
+type ExprNode struct {
+	Op          byte      // the operator, or 0 at a leaf
+	Value       int       // the operand at a leaf
+	Left, Right *ExprNode // the operand subtrees of an operator
+}
+
+// IsLeaf is true iff the node holds an operand.
+func (n *ExprNode) IsLeaf() bool { return n.Op == 0 }
+
+// String displays the operator or operand at this node (not the whole tree).
+func (n *ExprNode) String() string {
+	if n.IsLeaf() {
+		return strconv.Itoa(n.Value)
+	}
+	return string(n.Op)
+}
+
+// Infix displays the whole tree as a fully parenthesized infix expression.
+func (n *ExprNode) Infix() string {
+	if n.IsLeaf() {
+		return n.String()
+	}
+	if n.Left == nil {
+		return "(" + n.String() + n.Right.Infix() + ")"
+	}
+	return "(" + n.Left.Infix() + n.String() + n.Right.Infix() + ")"
+}
+
+// Eval computes the value of the expression in the tree.
+// Pre: The tree has no division or modulo by zero
+// Pre violation: return 0 and an error indication
+// Normal return: the expression value and nil
+func (n *ExprNode) Eval() (int, error) {
+	if n.IsLeaf() {
+		return n.Value, nil
+	}
+	rightArg, err := n.Right.Eval()
+	if err != nil {
+		return 0, err
+	}
+	if n.Left == nil {
+		return -rightArg, nil
+	}
+	leftArg, err := n.Left.Eval()
+	if err != nil {
+		return 0, err
+	}
+	return applyOperator(n.Op, leftArg, rightArg)
+}
+
+// VisitPreorder applies f to every node in the tree rooted at n in preorder.
+func (n *ExprNode) VisitPreorder(f func(node *ExprNode)) {
+	if n == nil {
+		return
+	}
+	f(n)
+	n.Left.VisitPreorder(f)
+	n.Right.VisitPreorder(f)
+}
+
+// VisitInorder applies f to every node in the tree rooted at n inorder.
+func (n *ExprNode) VisitInorder(f func(node *ExprNode)) {
+	if n == nil {
+		return
+	}
+	n.Left.VisitInorder(f)
+	f(n)
+	n.Right.VisitInorder(f)
+}
+
+// VisitPostorder applies f to every node in the tree rooted at n in postorder.
+func (n *ExprNode) VisitPostorder(f func(node *ExprNode)) {
+	if n == nil {
+		return
+	}
+	n.Left.VisitPostorder(f)
+	n.Right.VisitPostorder(f)
+	f(n)
+}
+
+//////////////////////////////////////////////////////////////////////////
+// Prefix: These functions parse a prefix expression held in a string.
+
+// ParsePrefix uses recursion to parse a prefix expression into a tree.
+// Pre: The expression in s is well formed
+// Pre violation: return nil and an error indication
+// Normal return: the expression tree and nil
+func ParsePrefix(s string) (*ExprNode, error) {
+	current := NewTokenizerSkipSpace(s)
+	result, err := parsePrefix(current)
+	if err == nil && current.Char != '$' {
+		return nil, current.Errorf("Extra characters at the end of the expression")
+	}
+	return result, err
+}
+
+// parsePrefix is a private function that recursively parses a prefix
+// expression provided by a Tokenizer, just as evalPrefix evaluates one.
+func parsePrefix(current *Tokenizer) (*ExprNode, error) {
+	switch {
+	case current.Char == '$':
+		return nil, current.Errorf("Missing argument")
+	case isDigit(current.Char):
+		result := &ExprNode{Value: int(current.Char - '0')}
+		current.Next()
+		return result, nil
+	case !isOperator(current.Char):
+		return nil, current.Errorf("Illegal character %c", current.Char)
+	}
+	result := &ExprNode{Op: current.Char}
+	current.Next()
+	var err error
+	if result.Left, err = parsePrefix(current); err != nil {
+		return nil, err
+	}
+	if result.Right, err = parsePrefix(current); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+//////////////////////////////////////////////////////////////////////////
+// Infix: These functions parse an infix expression held in a string.
+
+// ParseInfix uses recursion to parse an infix expression into a tree. The tree
+// reflects the usual operator precedence, as in EvalInfixPrecedence: unary minus
+// binds tightest, then *, /, and %, then + and -, and binary operators of equal
+// precedence associate to the left.
+// Pre: Expression in s is well formed
+// Pre violation: return nil and an error indication
+// Normal return: the expression tree and nil
+// Strategy: An expression is a sequence of terms separated by + or -, and a term
+// is a sequence of factors separated by *, /, or %. A factor is a digit, a
+// parenthesized expression, or a unary minus followed by a factor. A private
+// function parses each of these, calling the others recursively.
+func ParseInfix(s string) (*ExprNode, error) {
+	current := NewTokenizerSkipSpace(s)
+	result, err := parseInfixExpression(current)
+	if err == nil && current.Char != '$' {
+		return nil, current.Errorf("Extra characters at the end of the expression")
+	}
+	return result, err
+}
+
+// parseInfixExpression parses a sequence of terms separated by + or -.
+func parseInfixExpression(current *Tokenizer) (*ExprNode, error) {
+	result, err := parseInfixTerm(current)
+	if err != nil {
+		return nil, err
+	}
+	for current.Char == '+' || current.Char == '-' {
+		op := current.Char
+		current.Next()
+		rightArg, err := parseInfixTerm(current)
+		if err != nil {
+			return nil, err
+		}
+		result = &ExprNode{Op: op, Left: result, Right: rightArg}
+	}
+	return result, nil
+}
+
+// parseInfixTerm parses a sequence of factors separated by *, /, or %.
+func parseInfixTerm(current *Tokenizer) (*ExprNode, error) {
+	result, err := parseInfixFactor(current)
+	if err != nil {
+		return nil, err
+	}
+	for current.Char == '*' || current.Char == '/' || current.Char == '%' {
+		op := current.Char
+		current.Next()
+		rightArg, err := parseInfixFactor(current)
+		if err != nil {
+			return nil, err
+		}
+		result = &ExprNode{Op: op, Left: result, Right: rightArg}
+	}
+	return result, nil
+}
+
+// parseInfixFactor parses a digit, a parenthesized expression, or a unary
+// minus followed by a factor.
+func parseInfixFactor(current *Tokenizer) (*ExprNode, error) {
+	switch {
+	case current.Char == '-':
+		current.Next()
+		operand, err := parseInfixFactor(current)
+		if err != nil {
+			return nil, err
+		}
+		return &ExprNode{Op: '-', Right: operand}, nil
+	case current.Char == '(':
+		current.Next()
+		result, err := parseInfixExpression(current)
+		if err != nil {
+			return nil, err
+		}
+		if current.Char != ')' {
+			return nil, current.Errorf("Missing right parenthesis")
+		}
+		current.Next()
+		return result, nil
+	case isDigit(current.Char):
+		result := &ExprNode{Value: int(current.Char - '0')}
+		current.Next()
+		return result, nil
+	}
+	return nil, current.Errorf("Missing argument")
+}
+
+//////////////////////////////////////////////////////////////////////////
+// Postfix: These functions parse a postfix expression held in a string.
+
+// ParsePostfix uses a stack to parse a postfix expression into a tree.
+// Pre: The expression in s is well formed
+// Pre violation: return nil and an error indication
+// Normal return: the expression tree and nil
+// Strategy: Push a leaf for every operand on the stack, and whenever an operator
+// is encountered, pop its right and left subtrees and push a node joining them.
+// At the end, the stack should contain the whole tree.
+func ParsePostfix(s string) (*ExprNode, error) {
+	current := NewTokenizerSkipSpace(s)
+	stack := new(stack.LinkedStack)
+	for current.Char != '$' {
+		if isDigit(current.Char) {
+			stack.Push(&ExprNode{Value: int(current.Char - '0')})
+		} else if isOperator(current.Char) {
+			rightArg, err := stack.Pop()
+			if err != nil {
+				return nil, current.Errorf("Missing right argument")
+			}
+			leftArg, err := stack.Pop()
+			if err != nil {
+				return nil, current.Errorf("Missing left argument")
+			}
+			stack.Push(&ExprNode{Op: current.Char, Left: leftArg.(*ExprNode), Right: rightArg.(*ExprNode)})
+		} else {
+			return nil, current.Errorf("Illegal character %c", current.Char)
+		}
+		current.Next()
+	}
+	result, err := stack.Pop()
+	if err != nil {
+		return nil, errors.New("Missing expression")
+	}
+	if !stack.Empty() {
+		return nil, errors.New("Too many arguments")
+	}
+	return result.(*ExprNode), nil
+}
@@ -0,0 +1,132 @@
+package recursion
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	parsers := []struct {
+		parse func(string) (*ExprNode, error)
+		name  string
+	}{
+		{ParsePrefix, "parse prefix"},
+		{ParseInfix, "parse infix"},
+		{ParsePostfix, "parse postfix"},
+	}
+	for _, p := range parsers {
+		if tree, err := p.parse(""); err == nil {
+			t.Errorf("%v fails on empty string with tree %v", p.name, tree.Infix())
+		}
+		if tree, err := p.parse("5"); err != nil {
+			t.Errorf("%v fails: %v", p.name, err)
+		} else if !tree.IsLeaf() || tree.Value != 5 || tree.Infix() != "5" {
+			t.Errorf("%v fails on 5 with tree %v", p.name, tree.Infix())
+		}
+		if tree, err := p.parse("56"); err == nil {
+			t.Errorf("%v fails on 56 with tree %v", p.name, tree.Infix())
+		}
+		if tree, err := p.parse("5a"); err == nil {
+			t.Errorf("%v fails on 5a with tree %v", p.name, tree.Infix())
+		}
+	}
+
+	// each expression as prefix, infix, and postfix, and its fully parenthesized form
+	expected := []struct {
+		prefix, infix, postfix, parenthesized string
+	}{
+		{"+56", "5+6", "56+", "(5+6)"},
+		{"*+56-72", "(5+6)*(7-2)", "56+72-*", "((5+6)*(7-2))"},
+		{"-+5*672", "5+6*7-2", "567*+2-", "((5+(6*7))-2)"},
+		{"+-8*72%-643", "8 - 7*2 + (6-4) % 3", "872*-64-3%+", "((8-(7*2))+((6-4)%3))"},
+		{"--943", "9-4-3", "94-3-", "((9-4)-3)"},
+	}
+	for _, e := range expected {
+		for _, p := range []struct {
+			parse func(string) (*ExprNode, error)
+			name  string
+			s     string
+		}{
+			{ParsePrefix, "parse prefix", e.prefix},
+			{ParseInfix, "parse infix", e.infix},
+			{ParsePostfix, "parse postfix", e.postfix},
+		} {
+			tree, err := p.parse(p.s)
+			if err != nil {
+				t.Errorf("%v fails on %v: %v", p.name, p.s, err)
+				continue
+			}
+			if tree.Infix() != e.parenthesized {
+				t.Errorf("%v on %v should give %v but gives %v", p.name, p.s, e.parenthesized, tree.Infix())
+			}
+
+			// the traversals reproduce the expression without parentheses
+			for _, v := range []struct {
+				visit    func(f func(*ExprNode))
+				name, in string
+			}{
+				{tree.VisitPreorder, "preorder", e.prefix},
+				{tree.VisitPostorder, "postorder", e.postfix},
+			} {
+				result := ""
+				v.visit(func(n *ExprNode) { result += n.String() })
+				if result != v.in {
+					t.Errorf("%v on %v: %v traversal should give %v but gives %v", p.name, p.s, v.name, v.in, result)
+				}
+			}
+			result := ""
+			tree.VisitInorder(func(n *ExprNode) { result += n.String() })
+			if result != removeParens(e.parenthesized) {
+				t.Errorf("%v on %v: inorder traversal should give %v but gives %v", p.name, p.s, removeParens(e.parenthesized), result)
+			}
+		}
+	}
+
+	// infix parsing handles unary minus, precedence, and errors
+	tree, err := ParseInfix("-2+3*-(4-1)")
+	if err != nil {
+		t.Errorf("parse infix fails on -2+3*-(4-1): %v", err)
+	} else if tree.Infix() != "((-2)+(3*(-(4-1))))" {
+		t.Errorf("parse infix on -2+3*-(4-1) gives %v", tree.Infix())
+	} else if v, err := tree.Eval(); err != nil || v != -11 {
+		t.Errorf("-2+3*-(4-1) should evaluate to -11 but gives %v, %v", v, err)
+	}
+	for _, s := range []string{"(5+6", "5+6)", "5+", "+56", "()", "5(6)"} {
+		if tree, err := ParseInfix(s); err == nil {
+			t.Errorf("parse infix fails on %v with tree %v", s, tree.Infix())
+		}
+	}
+	if tree, err := ParsePostfix("5+"); err == nil {
+		t.Errorf("parse postfix fails on 5+ with tree %v", tree.Infix())
+	}
+	if tree, err := ParsePrefix("+5"); err == nil {
+		t.Errorf("parse prefix fails on +5 with tree %v", tree.Infix())
+	}
+
+	// a parsed tree evaluates just as the expression does
+	for _, s := range []string{"5+6*7-2", "(5+6)*(7-2)", "9%4*3-8/2", "-(7/2)"} {
+		tree, err := ParseInfix(s)
+		if err != nil {
+			t.Errorf("parse infix fails on %v: %v", s, err)
+			continue
+		}
+		v, err := tree.Eval()
+		w, _ := EvalInfixPrecedence(s)
+		if err != nil || v != w {
+			t.Errorf("tree for %v evaluates to %v, %v but the expression is %v", s, v, err, w)
+		}
+	}
+	if tree, err := ParseInfix("5/(2-2)"); err != nil {
+		t.Errorf("parse infix fails on 5/(2-2): %v", err)
+	} else if v, err := tree.Eval(); err == nil {
+		t.Errorf("tree for 5/(2-2) should not evaluate but gives %v", v)
+	}
+}
+
+// removeParens drops the parentheses from an infix expression.
+func removeParens(s string) string {
+	result := ""
+	for _, ch := range s {
+		if ch != '(' && ch != ')' {
+			result += string(ch)
+		}
+	}
+	return result
+}
@@ -2,41 +2,90 @@
 // string one by one. The strings package provides a Reader for this, but it convenient to
 // have an even more abstract view of things. The Tokenizer type packages up a string
 // reader and the current byte in the string along with methods to advance or back-up
-// one byte.
+// one byte. A Tokenizer may also be made to skip over spaces and tabs. It keeps track
+// of where the current byte is in the string so that errors can say where they occur.
 
 package recursion
 
-import "strings"
+import (
+	"fmt"
+	"io"
+	"strings"
+)
 
 type Tokenizer struct {
-	reader *strings.Reader // source for reading chars
-	Char   byte            // the current char in string; '$' if no more
+	source    string          // the string being read
+	reader    *strings.Reader // source for reading chars
+	Char      byte            // the current char in string; '$' if no more
+	position  int             // index of Char in source; len(source) if no more
+	skipSpace bool            // whether spaces and tabs are passed over
 }
 
 // Create a new Tokenizer: the char field will contain the first byte in
 // string s, or $ if s is empty.
 func NewTokenizer(s string) *Tokenizer {
 	result := new(Tokenizer)
+	result.source = s
 	result.reader = strings.NewReader(s)
 	result.Next()
 	return result
 }
 
+// Create a new Tokenizer that passes over spaces and tabs, so the char field
+// only ever holds other bytes in string s, or $ if there are none left.
+func NewTokenizerSkipSpace(s string) *Tokenizer {
+	result := new(Tokenizer)
+	result.source = s
+	result.reader = strings.NewReader(s)
+	result.skipSpace = true
+	result.Next()
+	return result
+}
+
 // Next advances to the next byte in the string and puts it in t.Char.
 // If the string is exhausted, then t.Char == '$'
 func (t *Tokenizer) Next() {
-	if t.reader.Len() == 0 {
-		t.Char = '$'
-	} else {
+	for {
+		if t.reader.Len() == 0 {
+			t.Char, t.position = '$', len(t.source)
+			return
+		}
 		t.Char, _ = t.reader.ReadByte()
+		t.position = len(t.source) - t.reader.Len() - 1
+		if !t.skipSpace || !isSpace(t.Char) {
+			return
+		}
+	}
+}
+
+// Peek returns the byte that Next would put in t.Char, without advancing.
+// If the string is exhausted, then Peek returns '$'.
+func (t *Tokenizer) Peek() byte {
+	position, _ := t.reader.Seek(0, io.SeekCurrent)
+	defer t.reader.Seek(position, io.SeekStart)
+	for t.reader.Len() != 0 {
+		ch, _ := t.reader.ReadByte()
+		if !t.skipSpace || !isSpace(ch) {
+			return ch
+		}
 	}
+	return '$'
 }
 
 // Last backs-up to the previous byte in the string and puts it in t.Char.
+// If the Tokenizer skips spaces, it backs up past any spaces and tabs too.
 // Pre: at least two characters have been read
 // Pre violation: panic
 // Normal return: t.Char is set to the previous character read
 func (t *Tokenizer) Last() {
+	t.backUp()
+	for t.skipSpace && isSpace(t.Char) {
+		t.backUp()
+	}
+}
+
+// backUp moves the reader back to the byte before t.Char and puts it in t.Char.
+func (t *Tokenizer) backUp() {
 	if err := t.reader.UnreadByte(); err != nil {
 		panic(err)
 	}
@@ -44,4 +93,32 @@ func (t *Tokenizer) Last() {
 		panic(err)
 	}
 	t.Char, _ = t.reader.ReadByte()
+	t.position = len(t.source) - t.reader.Len() - 1
+}
+
+// Position returns the index in the string of the byte in t.Char, or the
+// length of the string if it is exhausted.
+func (t *Tokenizer) Position() int { return t.position }
+
+// Errorf makes an error whose message is formatted from format and args as by
+// fmt.Sprintf, followed by the position of t.Char and the string on a line of
+// its own with a caret under t.Char on the next line, as in
+//
+//	Illegal character # at position 2
+//	5+#3
+//	  ^
+func (t *Tokenizer) Errorf(format string, args ...interface{}) error {
+	indent := []byte(t.source[:t.position])
+	for i, ch := range indent {
+		if ch != '\t' {
+			indent[i] = ' '
+		}
+	}
+	return fmt.Errorf("%s at position %d\n%s\n%s^",
+		fmt.Sprintf(format, args...), t.position, t.source, indent)
+}
+
+// isSpace determines whether a character is a space or a tab.
+func isSpace(ch byte) bool {
+	return ch == ' ' || ch == '\t'
 }
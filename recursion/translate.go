@@ -7,9 +7,8 @@
 package recursion
 
 import (
-	"containers"
+	"containers/stack"
 	"errors"
-	"fmt"
 	//"strings"
 )
 
@@ -25,7 +24,7 @@ func Prefix2InfixRecursive(s string) (string, error) {
 	current := NewTokenizer(s)
 	result, err := prefix2otherfix(current, "infix")
 	if err == nil && current.Char != '$' {
-		return "", errors.New("Extra characters at the end of the expression")
+		return "", current.Errorf("Extra characters at the end of the expression")
 	}
 	return result, err
 }
@@ -39,7 +38,7 @@ func Prefix2PostfixRecursive(s string) (string, error) {
 	current := NewTokenizer(s)
 	result, err := prefix2otherfix(current, "postfix")
 	if err == nil && current.Char != '$' {
-		return "", errors.New("Extra characters at the end of the expression")
+		return "", current.Errorf("Extra characters at the end of the expression")
 	}
 	return result, err
 }
@@ -56,7 +55,7 @@ func Prefix2PostfixRecursive(s string) (string, error) {
 // the two operand expressions.
 func prefix2otherfix(current *Tokenizer, fixity string) (string, error) {
 	if current.Char == '$' {
-		return "", errors.New("Missing argument")
+		return "", current.Errorf("Missing argument")
 	}
 
 	// handle the case of a single digit
@@ -132,8 +131,8 @@ func Prefix2Postfix(s string) (string, error) {
 // expression, there should be just an 'e' on the opStack and the final
 // expression on the expStack.
 func prefix2other(current *Tokenizer, fixity string) (string, error) {
-	opStack := containers.NewLinkedStack()
-	expStack := containers.NewLinkedStack()
+	opStack := new(stack.LinkedStack)
+	expStack := new(stack.LinkedStack)
 
 	// process the entire string unless an error is encountered
 	for current.Char != '$' {
@@ -145,11 +144,11 @@ func prefix2other(current *Tokenizer, fixity string) (string, error) {
 			for err == nil && op == 'e' {
 				opStack.Pop()
 				if op, err = opStack.Pop(); err != nil {
-					return "", errors.New("Missing operator")
+					return "", current.Errorf("Missing operator")
 				}
 				var leftArg string // argument from the stack
 				if exp, err := expStack.Pop(); err != nil {
-					return "", errors.New("Missing left argument")
+					return "", current.Errorf("Missing left argument")
 				} else {
 					leftArg = exp.(string)
 				}
@@ -166,23 +165,23 @@ func prefix2other(current *Tokenizer, fixity string) (string, error) {
 			expStack.Push(rightArg)
 			opStack.Push('e')
 		} else {
-			return "", errors.New(fmt.Sprintf("Illegal character %v", current.Char))
+			return "", current.Errorf("Illegal character %c", current.Char)
 		}
 		current.Next()
 	}
 
 	// if all is well, v should be on the opStack and the result on the expStack
 	if op, err := opStack.Pop(); err != nil || op != 'e' {
-		return "", errors.New("Missing argument")
+		return "", current.Errorf("Missing argument")
 	}
-	if !opStack.IsEmpty() {
-		return "", errors.New("Missing argument")
+	if !opStack.Empty() {
+		return "", current.Errorf("Missing argument")
 	}
 	result, err := expStack.Pop()
 	if err != nil {
-		return "", errors.New("Missing argument")
+		return "", current.Errorf("Missing argument")
 	}
-	if !expStack.IsEmpty() {
+	if !expStack.Empty() {
 		return "", errors.New("Too many arguments")
 	}
 	return result.(string), nil
@@ -200,7 +199,7 @@ func Infix2PrefixRecursive(s string) (string, error) {
 	current := NewTokenizer(s)
 	result, err := infix2otherfix(current, "prefix")
 	if err == nil && current.Char != '$' {
-		return "", errors.New("Extra characters at the end of the expression")
+		return "", current.Errorf("Extra characters at the end of the expression")
 	}
 	return result, err
 }
@@ -214,7 +213,7 @@ func Infix2PostfixRecursive(s string) (string, error) {
 	current := NewTokenizer(s)
 	result, err := infix2otherfix(current, "postfix")
 	if err == nil && current.Char != '$' {
-		return "", errors.New("Extra characters at the end of the expression")
+		return "", current.Errorf("Extra characters at the end of the expression")
 	}
 	return result, err
 }
@@ -235,12 +234,12 @@ func infix2otherfix(current *Tokenizer, fixity string) (result string, err error
 			return
 		}
 		if current.Char != ')' {
-			return "", errors.New("Missing right parenthesis")
+			return "", current.Errorf("Missing right parenthesis")
 		}
 	} else if isDigit(current.Char) {
 		result = string(current.Char)
 	} else {
-		return "", errors.New("Missing left argument")
+		return "", current.Errorf("Missing left argument")
 	}
 	current.Next()
 
@@ -255,12 +254,12 @@ func infix2otherfix(current *Tokenizer, fixity string) (result string, err error
 				return
 			}
 			if current.Char != ')' {
-				return "", errors.New("Missing right parenthesis")
+				return "", current.Errorf("Missing right parenthesis")
 			}
 		} else if isDigit(current.Char) {
 			rightArg = string(current.Char)
 		} else {
-			return "", errors.New("Missing right argument")
+			return "", current.Errorf("Missing right argument")
 		}
 		current.Next()
 		switch {
@@ -315,8 +314,8 @@ func Infix2Postfix(s string) (string, error) {
 // and push the result on the expStack, as long as the opStack has an
 // operator on it. The result should be in the expStack at the end.
 func infix2other(current *Tokenizer, fixity string) (string, error) {
-	opStack := containers.NewLinkedStack()
-	expStack := containers.NewLinkedStack()
+	opStack := new(stack.LinkedStack)
+	expStack := new(stack.LinkedStack)
 	for current.Char != '$' {
 		if isOperator(current.Char) || current.Char == '(' {
 			opStack.Push(current.Char)
@@ -325,22 +324,22 @@ func infix2other(current *Tokenizer, fixity string) (string, error) {
 				expStack.Push(string(current.Char))
 			} else if current.Char == ')' {
 				if op, err := opStack.Top(); err != nil || op.(byte) != '(' {
-					return "", errors.New("Missing left parenthesis")
+					return "", current.Errorf("Missing left parenthesis")
 				}
 				opStack.Pop()
 			} else {
-				return "", errors.New("Illegal character in expression")
+				return "", current.Errorf("Illegal character in expression")
 			}
 			op, err := opStack.Top()
 			if err == nil && isOperator(op.(byte)) {
 				opStack.Pop()
 				rightArg, err := expStack.Pop()
 				if err != nil {
-					return "", errors.New("Missing right argument")
+					return "", current.Errorf("Missing right argument")
 				}
 				leftArg, err := expStack.Pop()
 				if err != nil {
-					return "", errors.New("Missing left argument")
+					return "", current.Errorf("Missing left argument")
 				}
 				switch {
 				case fixity == "prefix":
@@ -354,14 +353,14 @@ func infix2other(current *Tokenizer, fixity string) (string, error) {
 		}
 		current.Next()
 	}
-	if !opStack.IsEmpty() {
-		return "", errors.New("Missing argument")
+	if !opStack.Empty() {
+		return "", current.Errorf("Missing argument")
 	}
 	result, err := expStack.Pop()
 	if err != nil {
 		return "", errors.New("Missing expression")
 	}
-	if !expStack.IsEmpty() {
+	if !expStack.Empty() {
 		return "", errors.New("Too many arguments")
 	}
 	return result.(string), nil
@@ -379,7 +378,7 @@ func Postfix2PrefixRecursive(s string) (string, error) {
 	current := NewTokenizer(s)
 	result, err := postfix2otherfix(current, "prefix")
 	if err == nil && current.Char != '$' {
-		return "", errors.New("Extra characters at the end of the expression")
+		return "", current.Errorf("Extra characters at the end of the expression")
 	}
 	return result, err
 }
@@ -393,7 +392,7 @@ func Postfix2InfixRecursive(s string) (string, error) {
 	current := NewTokenizer(s)
 	result, err := postfix2otherfix(current, "infix")
 	if err == nil && current.Char != '$' {
-		return "", errors.New("Extra characters at the end of the expression")
+		return "", current.Errorf("Extra characters at the end of the expression")
 	}
 	return result, err
 }
@@ -408,22 +407,20 @@ func Postfix2InfixRecursive(s string) (string, error) {
 // strategy: Transform the infix expression from left to right, with recursive
 // calls to handle parenthesized sub-expressions.
 // strategy: The first character must be a digit, so remember it as the leftArg.
-// Assume the next digit is the rightArg. If the character after that is not an
-// operator, then back up one character and call postfix2otherfix recursively to
-// evaluate the right operand expression. When the operator is finally found,
+// If the next digit is followed by an operator, it is the rightArg; otherwise,
+// call postfix2otherfix recursively to translate the right operand expression
+// that starts with it. When the operator is finally found,
 // apply it to leftArg and rightArg and leave the result in leftArg. Look for
 // another digit as the start of a possible following expression and repeat.
 func postfix2otherfix(current *Tokenizer, fixity string) (result string, err error) {
 	if !isDigit(current.Char) {
-		return "", errors.New("Missing argument")
+		return "", current.Errorf("Missing argument")
 	}
 	leftArg := string(current.Char)
 	current.Next()
 	for isDigit(current.Char) {
-		rightArg := string(current.Char)
-		current.Next()
-		if isDigit(current.Char) {
-			current.Last()
+		var rightArg string
+		if isDigit(current.Peek()) {
 			for {
 				if rightArg, err = postfix2otherfix(current, fixity); err != nil {
 					return "", err
@@ -432,9 +429,12 @@ func postfix2otherfix(current *Tokenizer, fixity string) (result string, err err
 					break
 				}
 			}
+		} else {
+			rightArg = string(current.Char)
+			current.Next()
 		}
 		if current.Char == '$' {
-			return "", errors.New("Missing operator")
+			return "", current.Errorf("Missing operator")
 		}
 		switch {
 		case fixity == "prefix":
@@ -485,18 +485,18 @@ func Postfix2Infix(s string) (string, error) {
 // encountered, apply it to the top two values in the stack and push the
 // result back on the stack. At the end, the stack should contain the result.
 func postfix2other(current *Tokenizer, fixity string) (string, error) {
-	stack := containers.NewLinkedStack() // expressions during evaluation
+	stack := new(stack.LinkedStack) // expressions during evaluation
 	for current.Char != '$' {
 		if isDigit(current.Char) {
 			stack.Push(string(current.Char))
 		} else {
 			rightArg, err := stack.Pop()
 			if err != nil {
-				return "", errors.New("Missing right argument")
+				return "", current.Errorf("Missing right argument")
 			}
 			leftArg, err := stack.Pop()
 			if err != nil {
-				return "", errors.New("Missing left argument")
+				return "", current.Errorf("Missing left argument")
 			}
 			switch {
 			case fixity == "prefix":
@@ -513,7 +513,7 @@ func postfix2other(current *Tokenizer, fixity string) (string, error) {
 	if err != nil {
 		return "", errors.New("Missing expression")
 	}
-	if !stack.IsEmpty() {
+	if !stack.Empty() {
 		return "", errors.New("Too many arguments")
 	}
 	return result.(string), nil
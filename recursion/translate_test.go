@@ -51,7 +51,7 @@ func testPrefix2Postfix(t *testing.T, translate func(string) (string, error), na
 	if result, err := translate("+-8*72%-643"); err != nil {
 		t.Errorf("%v fails: %v", name, err)
 	} else if result != "872*-64-3%+" {
-		t.Errorf("%v fails on +-8*72%-643 with result %v", name, result)
+		t.Errorf("%v fails on +-8*72%%-643 with result %v", name, result)
 	}
 }
 
@@ -88,7 +88,7 @@ func testPrefix2Infix(t *testing.T, translate func(string) (string, error), name
 	if result, err := translate("+-8*72%-643"); err != nil {
 		t.Errorf("%v fails: %v", name, err)
 	} else if result != "((8-(7*2))+((6-4)%3))" {
-		t.Errorf("%v fails on +-8*72%-643 with result %v", name, result)
+		t.Errorf("%v fails on +-8*72%%-643 with result %v", name, result)
 	}
 }
 
@@ -205,9 +205,9 @@ func testPostfix2Prefix(t *testing.T, translate func(string) (string, error), na
 		t.Errorf("%v fails on 56*71-3+* with result %v", name, result)
 	}
 	if result, err := translate("12+31/43%+42**+"); err != nil {
-		t.Errorf("%v fails on 12+31/43%+42**+: %v", name, err)
+		t.Errorf("%v fails on 12+31/43%%+42**+: %v", name, err)
 	} else if result != "++12*+/31%43*42" {
-		t.Errorf("%v fails on 12+31/43%+42**+ with result %v", name, result)
+		t.Errorf("%v fails on 12+31/43%%+42**+ with result %v", name, result)
 	}
 }
 
@@ -248,8 +248,8 @@ func testPostfix2Infix(t *testing.T, translate func(string) (string, error), nam
 		t.Errorf("%v fails on 56*71-3+* with result %v", name, result)
 	}
 	if result, err := translate("12+31/43%+42**+"); err != nil {
-		t.Errorf("%v fails on 12+31/43%+42**+: %v", name, err)
+		t.Errorf("%v fails on 12+31/43%%+42**+: %v", name, err)
 	} else if result != "((1+2)+(((3/1)+(4%3))*(4*2)))" {
-		t.Errorf("%v fails on 12+31/43%+42**+ with result %v", name, result)
+		t.Errorf("%v fails on 12+31/43%%+42**+ with result %v", name, result)
 	}
 }
@@ -49,3 +49,40 @@ func BinarySearch(a []int, key int) (int, bool) {
 	}
 	return -1, false
 }
+
+// LowerBound returns the first index at which key could be inserted into a
+// while keeping it sorted, which is the index of the first value not less
+// than key, or len(a) if there is none.
+// Pre: the slice is sorted
+// Pre violation: undefined behavior (not checked)
+func LowerBound(a []int, key int) int {
+	lb, ub := 0, len(a)
+	for lb < ub {
+		m := (lb + ub) / 2
+		if a[m] < key {
+			lb = m + 1
+		} else {
+			ub = m
+		}
+	}
+	return lb
+}
+
+// UpperBound returns the last index at which key could be inserted into a
+// while keeping it sorted, which is the index of the first value greater
+// than key, or len(a) if there is none. The values equal to key are
+// a[LowerBound(a,key):UpperBound(a,key)].
+// Pre: the slice is sorted
+// Pre violation: undefined behavior (not checked)
+func UpperBound(a []int, key int) int {
+	lb, ub := 0, len(a)
+	for lb < ub {
+		m := (lb + ub) / 2
+		if a[m] <= key {
+			lb = m + 1
+		} else {
+			ub = m
+		}
+	}
+	return lb
+}
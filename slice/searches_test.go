@@ -35,3 +35,26 @@ func testSearch(t *testing.T, a []int, key int, search func([]int, int) (int, bo
 		t.Errorf("Search %s thinks it found a value at %v\n", name, i)
 	}
 }
+
+func TestBounds(t *testing.T) {
+	if i, isFound := BinarySearch(nil, 3); isFound {
+		t.Errorf("BinarySearch found 3 at %v in an empty slice", i)
+	}
+	if lb, ub := LowerBound(nil, 3), UpperBound(nil, 3); lb != 0 || ub != 0 {
+		t.Errorf("Bounds of 3 in an empty slice should be 0 and 0 but are %v and %v", lb, ub)
+	}
+	a := []int{1, 3, 3, 3, 5, 8, 8, 13}
+	data := []struct{ key, lb, ub int }{
+		{0, 0, 0}, {1, 0, 1}, {2, 1, 1}, {3, 1, 4}, {4, 4, 4}, {5, 4, 5},
+		{8, 5, 7}, {10, 7, 7}, {13, 7, 8}, {20, 8, 8}}
+	for _, d := range data {
+		lb, ub := LowerBound(a, d.key), UpperBound(a, d.key)
+		if lb != d.lb || ub != d.ub {
+			t.Errorf("Bounds of %v in %v should be %v and %v but are %v and %v", d.key, a, d.lb, d.ub, lb, ub)
+		}
+		i, isFound := BinarySearch(a, d.key)
+		if isFound != (d.lb < d.ub) || (isFound && (i < d.lb || d.ub <= i)) {
+			t.Errorf("BinarySearch for %v in %v returned %v, %v", d.key, a, i, isFound)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package slice
+
+import (
+	"math/rand"
+	"time"
+)
+
+func init() {
+	rand.Seed(int64(time.Now().UnixNano()))
+}
+
+// Fisher-Yates shuffle: put the values in a in a random order, with every
+// order equally likely. Working down from the end, each position in turn
+// swaps with a random position at or before it.
+func Shuffle(a []int) {
+	ShuffleFunc(len(a), func(i, j int) { a[i], a[j] = a[j], a[i] })
+}
+
+// Fisher-Yates shuffle of any n-element sequence, which swap exchanges the
+// elements at indices i and j of; for example, to shuffle a []string s use
+// ShuffleFunc(len(s), func(i, j int) { s[i], s[j] = s[j], s[i] }).
+func ShuffleFunc(n int, swap func(i, j int)) {
+	for i := n - 1; 0 < i; i-- {
+		swap(i, rand.Intn(i+1))
+	}
+}
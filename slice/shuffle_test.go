@@ -0,0 +1,43 @@
+package slice
+
+import "testing"
+
+func TestShuffle(t *testing.T) {
+	const n, trials = 6, 3000
+	counts := [n][n]int{} // counts[v][i] is how often value v landed at index i
+	a := make([]int, n)
+	for trial := 0; trial < trials; trial++ {
+		for i := range a {
+			a[i] = i
+		}
+		Shuffle(a)
+		seen := make([]bool, n)
+		for i, v := range a {
+			if v < 0 || n <= v || seen[v] {
+				t.Fatalf("Shuffle should permute 0..%v but gives %v", n-1, a)
+			}
+			seen[v] = true
+			counts[v][i]++
+		}
+	}
+	// each count is about trials/n = 500, so missing one is all but impossible
+	for v := range counts {
+		for i, c := range counts[v] {
+			if c == 0 {
+				t.Errorf("Shuffle never put %v at index %v in %v trials", v, i, trials)
+			}
+		}
+	}
+
+	s := []string{"a", "b", "c", "d"}
+	ShuffleFunc(len(s), func(i, j int) { s[i], s[j] = s[j], s[i] })
+	seen := map[string]bool{}
+	for _, x := range s {
+		seen[x] = true
+	}
+	if len(seen) != 4 {
+		t.Errorf("ShuffleFunc should permute [a b c d] but gives %v", s)
+	}
+	Shuffle(nil)
+	Shuffle([]int{7})
+}
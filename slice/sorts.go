@@ -9,6 +9,9 @@
 package slice
 
 import (
+	"containers"
+	"containers/dictionary"
+	"errors"
 	"math"
 )
 
@@ -127,6 +130,65 @@ func MergeSort(a []int) {
 	mergeInto(a, auxiliary)
 }
 
+// MergeSortIndices returns a permutation p of the indices of keys such that
+// keys[p[0]] <= keys[p[1]] <= ... with the indices of equal keys in increasing
+// order, so that p describes a stable sort of keys. This is useful for sorting
+// other data by int keys. The keys themselves are not changed.
+func MergeSortIndices(keys []int) []int {
+	result := make([]int, len(keys))
+	for i := range result {
+		result[i] = i
+	}
+	auxiliary := make([]int, len(keys))
+	var mergeSort func(lo, hi int)
+	mergeSort = func(lo, hi int) {
+		if hi-lo < 2 {
+			return
+		}
+		m := (lo + hi) / 2
+		mergeSort(lo, m)
+		mergeSort(m, hi)
+		copy(auxiliary[lo:hi], result[lo:hi])
+		j, k := lo, m
+		for i := lo; i < hi; i++ {
+			if k == hi || (j < m && keys[auxiliary[j]] <= keys[auxiliary[k]]) {
+				result[i], j = auxiliary[j], j+1
+			} else {
+				result[i], k = auxiliary[k], k+1
+			}
+		}
+	}
+	mergeSort(0, len(keys))
+	return result
+}
+
+// StableSort sorts data into the order given by less using a top-down merge
+// sort. The sort is stable: elements for which neither less(a,b) nor less(b,a)
+// holds keep their original relative order, so sorting records by a secondary
+// key and then by a primary key orders them by both keys.
+func StableSort(data []interface{}, less func(a, b interface{}) bool) {
+	auxiliary := make([]interface{}, len(data))
+	var mergeSort func(lo, hi int)
+	mergeSort = func(lo, hi int) {
+		if hi-lo < 2 {
+			return
+		}
+		m := (lo + hi) / 2
+		mergeSort(lo, m)
+		mergeSort(m, hi)
+		copy(auxiliary[lo:hi], data[lo:hi])
+		j, k := lo, m
+		for i := lo; i < hi; i++ {
+			if k == hi || (j < m && !less(auxiliary[k], auxiliary[j])) {
+				data[i], j = auxiliary[j], j+1
+			} else {
+				data[i], k = auxiliary[k], k+1
+			}
+		}
+	}
+	mergeSort(0, len(data))
+}
+
 // ConcurrentMergesort using an auxiliary slice of size len(a) taht sorts sub-lists
 // in goroutines if the sub-lists are bigger than the goThreshold.
 func ConcurrentMergeSort(a []int) {
@@ -134,7 +196,11 @@ func ConcurrentMergeSort(a []int) {
 	var mergeInto func([]int, []int, chan bool)
 
 	// merge sub-lists upward
+	// done (if not nil) is signalled when the merge is complete
 	mergeInto = func(dst []int, src []int, done chan bool) {
+		if done != nil {
+			defer func() { done <- true }()
+		}
 		if len(dst) < 2 {
 			return
 		}
@@ -143,11 +209,11 @@ func ConcurrentMergeSort(a []int) {
 			mergeInto(src[:m], dst[:m], nil)
 			mergeInto(src[m:], dst[m:], nil)
 		} else {
-			done := make(chan bool)
-			go mergeInto(src[:m], dst[:m], done)
-			go mergeInto(src[m:], dst[m:], done)
-			<-done
-			<-done
+			childDone := make(chan bool)
+			go mergeInto(src[:m], dst[:m], childDone)
+			go mergeInto(src[m:], dst[m:], childDone)
+			<-childDone
+			<-childDone
 		}
 		j, k := 0, m
 		for i := 0; i < len(dst); i++ {
@@ -163,9 +229,6 @@ func ConcurrentMergeSort(a []int) {
 				dst[i], k = src[k], k+1
 			}
 		}
-		if done != nil {
-			done <- true
-		}
 	}
 
 	auxiliary := make([]int, len(a))
@@ -179,11 +242,18 @@ func Quicksort(a []int) {
 		return
 	}
 
-	// use the last element as the pivot
+	// recursively sort the sublists on either side of the pivot
+	i := partition(a)
+	Quicksort(a[:i])
+	Quicksort(a[i+1:])
+}
+
+// partition rearranges a (with len(a) >= 2) around its last element as the
+// pivot so that no value before the pivot is larger and no value after it is
+// smaller, and returns the pivot's new index.
+func partition(a []int) int {
 	ub := len(a) - 1
 	pivot := a[ub]
-
-	// partition the list
 	i, j := -1, ub
 	for i < j {
 		for i++; a[i] < pivot; i++ {
@@ -193,10 +263,70 @@ func Quicksort(a []int) {
 		a[i], a[j] = a[j], a[i]
 	}
 	a[j], a[i], a[ub] = a[i], pivot, a[j]
+	return i
+}
 
-	// recursively sort the sublists
-	Quicksort(a[:i])
-	Quicksort(a[i+1:])
+// Quicksort with 3-way partitioning (the Dutch national flag problem): the
+// values less than the pivot, equal to it, and greater than it are gathered in
+// a single pass, and only the less and greater parts are sorted recursively.
+// Every value equal to the pivot is thus placed once and for all, so slices
+// with many duplicate values are sorted much faster than by Quicksort. The
+// middle element is the pivot so that sorted input does no harm.
+func Quicksort3Way(a []int) {
+	if len(a) < 2 {
+		return
+	}
+
+	// a[:lt] < pivot, a[lt:i] == pivot, a[i:gt] unexamined, a[gt:] > pivot
+	pivot := a[len(a)/2]
+	lt, i, gt := 0, 0, len(a)
+	for i < gt {
+		switch {
+		case a[i] < pivot:
+			a[lt], a[i] = a[i], a[lt]
+			lt, i = lt+1, i+1
+		case pivot < a[i]:
+			gt--
+			a[i], a[gt] = a[gt], a[i]
+		default:
+			i++
+		}
+	}
+	Quicksort3Way(a[:lt])
+	Quicksort3Way(a[gt:])
+}
+
+// Quickselect: return the value that would be at index k if a were sorted.
+// Like quicksort, it partitions a around a pivot, but then it continues only
+// in the part containing index k, so it takes O(n) time on average. The
+// values in a are rearranged in the process.
+// Pre: 0 <= k < len(a)
+// Pre violation: panic
+func Select(a []int, k int) int {
+	if k < 0 || len(a) <= k {
+		panic("Select: k is out of range")
+	}
+	lo, hi := 0, len(a)
+	for 1 < hi-lo {
+		p := lo + partition(a[lo:hi])
+		switch {
+		case k < p:
+			hi = p
+		case p < k:
+			lo = p + 1
+		default:
+			return a[k]
+		}
+	}
+	return a[k]
+}
+
+// Median returns the middle value of a, or the lower of the two middle
+// values if len(a) is even. The values in a are rearranged.
+// Pre: len(a) > 0
+// Pre violation: panic
+func Median(a []int) int {
+	return Select(a, (len(a)-1)/2)
 }
 
 // Concurrent quicksort: add concurrency to basic quicksort with no other improvement.
@@ -210,7 +340,11 @@ func ConcurrentQuicksort(a []int) {
 	const goThreshold = 75000
 	var cqs func([]int, chan bool)
 
+	// done (if not nil) is signalled when the sort is complete
 	cqs = func(a []int, done chan bool) {
+		if done != nil {
+			defer func() { done <- true }()
+		}
 		if len(a) < 2 {
 			return
 		}
@@ -232,18 +366,15 @@ func ConcurrentQuicksort(a []int) {
 
 		// recursively sort the sublists
 		if goThreshold < len(a) {
-			done := make(chan bool)
-			go cqs(a[:i], done)
-			go cqs(a[i+1:], done)
-			<-done
-			<-done
+			childDone := make(chan bool)
+			go cqs(a[:i], childDone)
+			go cqs(a[i+1:], childDone)
+			<-childDone
+			<-childDone
 		} else {
 			cqs(a[:i], nil)
 			cqs(a[i+1:], nil)
 		}
-		if done != nil {
-			done <- true
-		}
 	}
 
 	cqs(a, nil)
@@ -350,8 +481,12 @@ func IntrospectiveSort(a []int) {
 	)
 	altSort := Heapsort
 
-	// ispectSort does the real work
+	// ispectSort does the real work; done (if not nil) is signalled when it is complete
 	ispectSort = func(a []int, recursionCount int, done chan bool) {
+		if done != nil {
+			defer func() { done <- true }()
+		}
+
 		// insertion sort small lists at the end
 		if len(a) < smallThreshold {
 			InsertionSort(a)
@@ -388,11 +523,11 @@ func IntrospectiveSort(a []int) {
 		// depending on depth, either recursively ispecSort or altSort the sublists
 		if 0 < recursionCount {
 			if goThreshold < len(a) {
-				done := make(chan bool)
-				go ispectSort(a[:i], recursionCount-1, done)
-				go ispectSort(a[i+1:], recursionCount-1, done)
-				<-done
-				<-done
+				childDone := make(chan bool)
+				go ispectSort(a[:i], recursionCount-1, childDone)
+				go ispectSort(a[i+1:], recursionCount-1, childDone)
+				<-childDone
+				<-childDone
 			} else {
 				ispectSort(a[:i], recursionCount-1, nil)
 				ispectSort(a[i+1:], recursionCount-1, nil)
@@ -401,14 +536,184 @@ func IntrospectiveSort(a []int) {
 			altSort(a[:i])
 			altSort(a[i+1:])
 		}
-		if done != nil {
-			done <- true
-		}
 	}
 
 	ispectSort(a, altThreshold, nil)
 }
 
+// SortDescending sorts a into non-increasing order by sorting it with
+// IntrospectiveSort and then reversing it. The extra linear pass costs far
+// less than the sort itself, and it leaves IntrospectiveSort as fast as it is.
+func SortDescending(a []int) {
+	IntrospectiveSort(a)
+	Reverse(a)
+}
+
+// minSiftDown makes a from i to maxIndex into a min-heap, assuming that the
+// subtrees below i are already min-heaps.
+func minSiftDown(a []int, i, maxIndex int) {
+	tmp := a[i]
+	for j := 2*i + 1; j <= maxIndex; j = 2*i + 1 {
+		if j < maxIndex && a[j+1] < a[j] {
+			j++
+		}
+		if tmp <= a[j] {
+			break
+		}
+		a[i], i = a[j], j
+	}
+	a[i] = tmp
+}
+
+// TopK returns the k largest values in a in descending order, or all of
+// them if k >= len(a), without sorting a. A min-heap holds the k largest
+// values seen so far, so each new value need only be compared with the
+// smallest of them, and the whole job takes O(n lg k) time.
+func TopK(a []int, k int) []int {
+	if k <= 0 {
+		return []int{}
+	}
+	if len(a) < k {
+		k = len(a)
+	}
+	heap := make([]int, k)
+	copy(heap, a[:k])
+	for i := (k - 2) / 2; 0 <= i; i-- {
+		minSiftDown(heap, i, k-1)
+	}
+	for _, x := range a[k:] {
+		if heap[0] < x {
+			heap[0] = x
+			minSiftDown(heap, 0, k-1)
+		}
+	}
+	for maxIndex := k - 1; 0 < maxIndex; maxIndex-- {
+		heap[0], heap[maxIndex] = heap[maxIndex], heap[0]
+		minSiftDown(heap, 0, maxIndex-1)
+	}
+	return heap
+}
+
+// Reverse puts the values in a in the opposite order.
+func Reverse(a []int) {
+	for i, j := 0, len(a)-1; i < j; i, j = i+1, j-1 {
+		a[i], a[j] = a[j], a[i]
+	}
+}
+
+// Counting sort for non-negative ints: count the occurrences of each value,
+// then write the values back in order. This takes O(n + max(a)) time and
+// space, so it is only sensible when the values are in a small range.
+// Pre: all values in a are non-negative
+// Pre violation: panic
+func CountingSort(a []int) {
+	max := 0
+	for _, x := range a {
+		if x < 0 {
+			panic("CountingSort: negative value")
+		}
+		if max < x {
+			max = x
+		}
+	}
+	if len(a) < 2 {
+		return
+	}
+	count := make([]int, max+1)
+	for _, x := range a {
+		count[x]++
+	}
+	i := 0
+	for x, c := range count {
+		for ; 0 < c; c-- {
+			a[i], i = x, i+1
+		}
+	}
+}
+
+// LSD radix sort for non-negative ints in base 256: a stable counting sort
+// on each byte of the values, from least to most significant, stopping once
+// the remaining bytes of every value are zero.
+// Pre: all values in a are non-negative
+// Pre violation: panic
+func RadixSort(a []int) {
+	const (
+		radixBits = 8
+		radix     = 1 << radixBits
+	)
+	max := 0
+	for _, x := range a {
+		if x < 0 {
+			panic("RadixSort: negative value")
+		}
+		if max < x {
+			max = x
+		}
+	}
+	if len(a) < 2 {
+		return
+	}
+	auxiliary := make([]int, len(a))
+	src, dst := a, auxiliary
+	for shift := uint(0); 0 < max>>shift; shift += radixBits {
+		var count [radix + 1]int
+		for _, x := range src {
+			count[(x>>shift)&(radix-1)+1]++
+		}
+		for d := 1; d <= radix; d++ {
+			count[d] += count[d-1]
+		}
+		for _, x := range src {
+			d := (x >> shift) & (radix - 1)
+			dst[count[d]], count[d] = x, count[d]+1
+		}
+		src, dst = dst, src
+	}
+	if &src[0] != &a[0] { // an odd number of passes left the result in auxiliary
+		copy(a, src)
+	}
+}
+
+// Bucket sort for float64 values in [0,1): put each value x into bucket
+// int(x*n) of n buckets, insertion sort each bucket, and concatenate them.
+// If the values are roughly uniformly distributed, each bucket holds a few
+// values and the sort takes O(n) expected time; if they are clustered, a few
+// buckets hold most of them and it degrades toward O(n^2). Values outside
+// [0,1), including infinities, go in the first or last bucket, so they are
+// still sorted correctly. They are clamped before conversion to an int,
+// which is undefined for values too big for an int. NaNs go in the last
+// bucket, but since they are unordered, where they end up is unspecified.
+func BucketSortFloat64(a []float64) {
+	n := len(a)
+	if n < 2 {
+		return
+	}
+	buckets := make([][]float64, n)
+	for _, x := range a {
+		b := n - 1
+		switch {
+		case x < 0:
+			b = 0
+		case x < 1:
+			if y := int(x * float64(n)); y < b { // x*n may round up to n
+				b = y
+			}
+		}
+		buckets[b] = append(buckets[b], x)
+	}
+	i := 0
+	for _, bucket := range buckets {
+		for j := 1; j < len(bucket); j++ {
+			tmp, k := bucket[j], j
+			for ; 0 < k && tmp < bucket[k-1]; k-- {
+				bucket[k] = bucket[k-1]
+			}
+			bucket[k] = tmp
+		}
+		i += copy(a[i:], bucket)
+	}
+}
+
 // IsSorted tests to see whether a slice is sorted
 func IsSorted(a []int) bool {
 	for i := 0; i < len(a)-1; i++ {
@@ -418,3 +723,113 @@ func IsSorted(a []int) bool {
 	}
 	return true
 }
+
+// IsSortedFunc tests to see whether an n-element sequence is sorted by less,
+// which reports whether the element at index i belongs before the one at j;
+// that is, whether no element belongs before the one preceding it.
+func IsSortedFunc(n int, less func(i, j int) bool) bool {
+	for i := 0; i < n-1; i++ {
+		if less(i+1, i) {
+			return false
+		}
+	}
+	return true
+}
+
+// MaxSubarraySum finds the maximum sum of a non-empty contiguous subarray
+// a[start:end] using Kadane's algorithm: the best sum ending at a[i] is either
+// a[i] alone or a[i] added to the best sum ending at a[i-1], whichever is more.
+// If every value is negative, the result is the single largest value.
+// pre: len(a) > 0
+// pre violation: return zeros and an error
+// normal return: the maximum sum, its bounds, and nil
+func MaxSubarraySum(a []int) (sum, start, end int, err error) {
+	if len(a) == 0 {
+		return 0, 0, 0, errors.New("MaxSubarraySum: the slice is empty")
+	}
+	sum, start, end = a[0], 0, 1
+	current, currentStart := a[0], 0
+	for i := 1; i < len(a); i++ {
+		if current <= 0 {
+			current, currentStart = a[i], i
+		} else {
+			current += a[i]
+		}
+		if sum < current {
+			sum, start, end = current, currentStart, i+1
+		}
+	}
+	return sum, start, end, nil
+}
+
+// EquilibriumIndex finds the smallest index i such that the sum of the
+// values before a[i] equals the sum of the values after it. One pass computes
+// the total, and a second keeps a running sum of the values to the left, so
+// the sum to the right of a[i] is the total less the left sum less a[i].
+// normal return: the index and true, or 0 and false if there is none
+func EquilibriumIndex(a []int) (int, bool) {
+	total := 0
+	for _, x := range a {
+		total += x
+	}
+	left := 0
+	for i, x := range a {
+		if left == total-left-x {
+			return i, true
+		}
+		left += x
+	}
+	return 0, false
+}
+
+// SliceGCD finds the greatest common divisor of all the values in a by
+// applying Euclid's algorithm to each value in turn and the GCD so far.
+// Since every int divides 0, zeros are ignored, so the GCD of a slice of
+// zeros is 0. The result is never negative.
+// pre: len(a) > 0
+// pre violation: return 0 and an error
+// normal return: the GCD and nil
+func SliceGCD(a []int) (int, error) {
+	if len(a) == 0 {
+		return 0, errors.New("SliceGCD: the slice is empty")
+	}
+	result := 0
+	for _, x := range a {
+		result = gcd(result, x)
+	}
+	return result, nil
+}
+
+// gcd uses Euclid's algorithm to find the non-negative GCD of a and b.
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+// PairsWithSum finds every pair of indices i < j such that a[i]+a[j] == target.
+// A HashMap from each value to the indices where it has been seen so far lets
+// each a[j] be matched against all earlier partners in one lookup, so the
+// work is linear in len(a) plus the number of pairs, on average. The pairs
+// are ordered by j and then by i.
+func PairsWithSum(a []int, target int) [][2]int {
+	result := [][2]int{}
+	seen := new(dictionary.HashMap) // value -> []int of indices holding it
+	for j, x := range a {
+		if indices, ok := seen.Get(containers.IntKey(target - x)); ok {
+			for _, i := range indices.([]int) {
+				result = append(result, [2]int{i, j})
+			}
+		}
+		var indices []int
+		if v, ok := seen.Get(containers.IntKey(x)); ok {
+			indices = v.([]int)
+		}
+		seen.Insert(containers.IntKey(x), append(indices, j))
+	}
+	return result
+}
@@ -2,6 +2,7 @@ package slice
 
 import (
 	"fmt"
+	"math"
 	"math/rand"
 	"sort"
 	"testing"
@@ -42,6 +43,7 @@ func TestSorts(t *testing.T) {
 	testSort(t, big, bigOracle, MergeSort, "Merge sort")
 	testSort(t, big, bigOracle, ConcurrentMergeSort, "Concurrent merge sort")
 	testSort(t, big, bigOracle, Quicksort, "Basic quicksort")
+	testSort(t, big, bigOracle, Quicksort3Way, "3-way quicksort")
 	testSort(t, big, bigOracle, ConcurrentQuicksort, "Concurrent quicksort")
 	testSort(t, big, bigOracle, Qsort, "Improved quicksort")
 	testSort(t, big, bigOracle, Heapsort, "Heapsort")
@@ -71,6 +73,547 @@ func benchmarkSort(b *testing.B, sort func([]int)) {
 //func BenchmarkQuicksort(b *testing.B)          { benchmarkSort(b, Quicksort) }
 //func BenchmarkQsort(b *testing.B)              { benchmarkSort(b, Qsort) }
 //func BenchmarkConcurrenQuicksort(b *testing.B) { benchmarkSort(b, ConcurrentQuicksort) }
-//func BenchmarkIntrospectiveSort(b *testing.B)  { benchmarkSort(b, IntrospectiveSort) }
+func BenchmarkIntrospectiveSort(b *testing.B)  { benchmarkSort(b, IntrospectiveSort) }
+func BenchmarkSortDescending(b *testing.B)     { benchmarkSort(b, SortDescending) }
 func BenchmarkMergeSort(b *testing.B)          { benchmarkSort(b, MergeSort) }
 func BenchmarkConcurrenMergeSort(b *testing.B) { benchmarkSort(b, ConcurrentMergeSort) }
+
+// benchmarkDuplicatesSort sorts a slice of mostly equal values: about one
+// value in a hundred differs from the rest.
+func benchmarkDuplicatesSort(b *testing.B, sort func([]int)) {
+	const n = 100000
+	data := make([]int, n)
+	for index := range data {
+		data[index] = 42
+		if rand.Intn(100) == 0 {
+			data[index] = rand.Int()
+		}
+	}
+	a := make([]int, n)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(a, data)
+		sort(a)
+	}
+}
+
+func BenchmarkDuplicatesQuicksort(b *testing.B)     { benchmarkDuplicatesSort(b, Quicksort) }
+func BenchmarkDuplicatesQuicksort3Way(b *testing.B) { benchmarkDuplicatesSort(b, Quicksort3Way) }
+
+func TestQuicksort3Way(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 3, 10, 1000, 100000} {
+		for _, distinct := range []int{1, 2, 10, n + 1} {
+			a := make([]int, n)
+			counts := make(map[int]int)
+			for i := range a {
+				a[i] = rand.Intn(distinct)
+				counts[a[i]]++
+			}
+			Quicksort3Way(a)
+			if !IsSorted(a) {
+				t.Errorf("Quicksort3Way failed on %v values with %v distinct", n, distinct)
+			}
+			for _, x := range a {
+				counts[x]--
+			}
+			for x, c := range counts {
+				if c != 0 {
+					t.Errorf("Quicksort3Way changed the number of %vs on %v values", x, n)
+				}
+			}
+		}
+	}
+}
+
+func TestSortDescending(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 3, 15, 16, 17, 100, 10000, 200000} {
+		a := make([]int, n)
+		for i := range a {
+			a[i] = rand.Intn(n/3 + 1)
+		}
+		oracle := make([]int, n)
+		copy(oracle, a)
+		sort.Sort(sort.Reverse(sort.IntSlice(oracle)))
+		SortDescending(a)
+		for i := range a {
+			if a[i] != oracle[i] {
+				t.Errorf("SortDescending failed on %v values at index %v", n, i)
+				break
+			}
+		}
+	}
+
+	// already sorted and reverse sorted input
+	a := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20}
+	SortDescending(a)
+	for i := range a {
+		if a[i] != 20-i {
+			t.Errorf("SortDescending of ascending values produced %v", a)
+			break
+		}
+	}
+	SortDescending(a)
+	if a[0] != 20 || a[19] != 1 {
+		t.Errorf("SortDescending of descending values produced %v", a)
+	}
+}
+
+func TestIsSortedFunc(t *testing.T) {
+	ascending := func(a []int) func(i, j int) bool { return func(i, j int) bool { return a[i] < a[j] } }
+	descending := func(a []int) func(i, j int) bool { return func(i, j int) bool { return a[j] < a[i] } }
+	data := []struct {
+		a                         []int
+		isAscending, isDescending bool
+	}{{[]int{}, true, true},
+		{[]int{7}, true, true},
+		{[]int{1, 2, 2, 5, 9}, true, false},
+		{[]int{9, 5, 2, 2, 1}, false, true},
+		{[]int{3, 3, 3}, true, true},
+		{[]int{1, 3, 2, 4}, false, false}}
+	for _, d := range data {
+		if IsSortedFunc(len(d.a), ascending(d.a)) != d.isAscending || IsSorted(d.a) != d.isAscending {
+			t.Errorf("IsSortedFunc of %v ascending should be %v", d.a, d.isAscending)
+		}
+		if IsSortedFunc(len(d.a), descending(d.a)) != d.isDescending {
+			t.Errorf("IsSortedFunc of %v descending should be %v", d.a, d.isDescending)
+		}
+	}
+
+	// check a descending sort with a descending less
+	a := make([]int, 1000)
+	for i := range a {
+		a[i] = rand.Intn(100)
+	}
+	SortDescending(a)
+	if !IsSortedFunc(len(a), descending(a)) || IsSortedFunc(len(a), ascending(a)) {
+		t.Error("IsSortedFunc is wrong for the result of SortDescending")
+	}
+}
+
+func TestReverse(t *testing.T) {
+	Reverse(nil)
+	data := [][]int{{}, {1}, {1, 2}, {1, 2, 3}, {4, 1, 3, 3, 9, 0}}
+	for _, d := range data {
+		a := make([]int, len(d))
+		copy(a, d)
+		Reverse(a)
+		for i := range a {
+			if a[i] != d[len(d)-1-i] {
+				t.Errorf("Reverse of %v should not be %v", d, a)
+				break
+			}
+		}
+		Reverse(a)
+		for i := range a {
+			if a[i] != d[i] {
+				t.Errorf("Reverse twice of %v should not be %v", d, a)
+				break
+			}
+		}
+	}
+}
+
+func TestCountingAndRadixSorts(t *testing.T) {
+	data := [][]int{nil, {0}, {0, 0, 0}, {3, 0, 2, 0, 3, 1}, {255, 256, 0, 65535, 65536, 7, 256}}
+	for _, limit := range []int{10, 1000, 1 << 20} {
+		a := make([]int, 50000)
+		for i := range a {
+			a[i] = rand.Intn(limit)
+		}
+		data = append(data, a)
+	}
+	for _, d := range data {
+		testIntSort(t, d, CountingSort, "Counting sort")
+		testIntSort(t, d, RadixSort, "Radix sort")
+	}
+
+	// values too big for a counting sort
+	big := make([]int, 10000)
+	for i := range big {
+		big[i] = rand.Int()
+	}
+	big[0], big[1] = 0, 1<<40
+	testIntSort(t, big, RadixSort, "Radix sort")
+
+	for _, sort := range []func([]int){CountingSort, RadixSort} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("Counting and radix sorts should panic on negative values")
+				}
+			}()
+			sort([]int{3, -1, 2})
+		}()
+	}
+}
+
+// testIntSort sorts a copy of d and checks it against quicksort.
+func testIntSort(t *testing.T, d []int, sort func([]int), name string) {
+	a := make([]int, len(d))
+	copy(a, d)
+	oracle := make([]int, len(d))
+	copy(oracle, d)
+	Quicksort(oracle)
+	sort(a)
+	if !IsSorted(a) {
+		t.Errorf("%s failed to sort %v values", name, len(a))
+	}
+	for i := range a {
+		if a[i] != oracle[i] {
+			t.Errorf("%s disagrees with quicksort at index %v", name, i)
+			break
+		}
+	}
+}
+
+func TestBucketSortFloat64(t *testing.T) {
+	data := [][]float64{nil, {0.5}, {0.9, 0.1}, {0.3, 0.3, 0.3}, {-2.5, 0.7, 1, 3.25, 0, 0.99},
+		{1e19, 0.5}, {math.Inf(1), 0.5, 0.2}, {0.4, math.Inf(-1), -1e300, 1e300, math.Inf(1), 0.6},
+		{math.MaxFloat64, -math.MaxFloat64, 0.999999999999999, 0}}
+	uniform := make([]float64, 50000)
+	for i := range uniform {
+		uniform[i] = rand.Float64()
+	}
+	data = append(data, uniform)
+
+	// clustered values all land in a few buckets
+	clustered := make([]float64, 3000)
+	for i := range clustered {
+		clustered[i] = 0.5 + rand.Float64()/1e6
+		if i%3 == 0 {
+			clustered[i] = 0.25
+		}
+	}
+	data = append(data, clustered)
+
+	for _, d := range data {
+		a := make([]float64, len(d))
+		copy(a, d)
+		oracle := make([]float64, len(d))
+		copy(oracle, d)
+		sort.Float64s(oracle)
+		BucketSortFloat64(a)
+		for i := range a {
+			if a[i] != oracle[i] {
+				t.Errorf("BucketSortFloat64 failed on %v values at index %v", len(d), i)
+				break
+			}
+		}
+	}
+}
+
+func TestSelect(t *testing.T) {
+	for _, n := range []int{1, 2, 3, 10, 101, 5000} {
+		a := make([]int, n)
+		for i := range a {
+			a[i] = rand.Intn(n)
+		}
+		sorted := make([]int, n)
+		copy(sorted, a)
+		sort.Ints(sorted)
+		for _, k := range []int{0, n / 4, n / 2, (n - 1) / 2, n - 1} {
+			b := make([]int, n)
+			copy(b, a)
+			if v := Select(b, k); v != sorted[k] {
+				t.Errorf("Select(%v) on %v values should be %v but is %v", k, n, sorted[k], v)
+			}
+		}
+		b := make([]int, n)
+		copy(b, a)
+		if m := Median(b); m != sorted[(n-1)/2] {
+			t.Errorf("Median of %v values should be %v but is %v", n, sorted[(n-1)/2], m)
+		}
+	}
+	if m := Median([]int{4, 1, 3, 2}); m != 2 {
+		t.Errorf("Median of [4 1 3 2] should be 2 but is %v", m)
+	}
+	if m := Median([]int{7, 7, 7, 1, 9}); m != 7 {
+		t.Errorf("Median of [7 7 7 1 9] should be 7 but is %v", m)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Select should panic when k is out of range")
+		}
+	}()
+	Select([]int{1, 2, 3}, 3)
+}
+
+func TestTopK(t *testing.T) {
+	if top := TopK([]int{3, 1, 2}, 0); len(top) != 0 {
+		t.Errorf("TopK with k = 0 should be empty but is %v", top)
+	}
+	if top := TopK([]int{3, 1, 2}, -2); len(top) != 0 {
+		t.Errorf("TopK with k < 0 should be empty but is %v", top)
+	}
+	if top := TopK(nil, 3); len(top) != 0 {
+		t.Errorf("TopK of an empty slice should be empty but is %v", top)
+	}
+	data := []struct {
+		a, top []int
+	}{{[]int{5, 1, 9, 3, 7}, []int{9, 7, 5}},
+		{[]int{4, 8, 8, 2, 8, 1}, []int{8, 8, 8}},
+		{[]int{2, 6, 6, 6, 1, 3}, []int{6, 6, 6}},
+		{[]int{1, 2}, []int{2, 1}},
+		{[]int{3, 5, 3, 5, 4}, []int{5, 5, 4}}}
+	for _, d := range data {
+		if top := TopK(d.a, 3); !sameInts(top, d.top) {
+			t.Errorf("TopK(%v, 3) should be %v but is %v", d.a, d.top, top)
+		}
+	}
+
+	// compare against the tail of a sorted copy
+	a := make([]int, 10000)
+	for i := range a {
+		a[i] = rand.Intn(500)
+	}
+	original := make([]int, len(a))
+	copy(original, a)
+	sorted := make([]int, len(a))
+	copy(sorted, a)
+	sort.Sort(sort.Reverse(sort.IntSlice(sorted)))
+	for _, k := range []int{1, 10, 100, 9999, 10000, 20000} {
+		expected := sorted
+		if k < len(sorted) {
+			expected = sorted[:k]
+		}
+		if top := TopK(a, k); !sameInts(top, expected) {
+			t.Errorf("TopK(a, %v) disagrees with sorting", k)
+		}
+	}
+	if !sameInts(a, original) {
+		t.Error("TopK should not change its argument")
+	}
+}
+
+// sameInts is true iff a and b hold the same values in the same order.
+func sameInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// TestConcurrentSortsStress repeatedly sorts slices big enough for the
+// concurrent sorts to spawn goroutines; run it with -race to check for data
+// races as well as deadlocks.
+func TestConcurrentSortsStress(t *testing.T) {
+	const n = 150001 // above every goroutine threshold
+	rounds := 10
+	if testing.Short() {
+		rounds = 2
+	}
+	sorts := []struct {
+		name string
+		sort func([]int)
+	}{{"Concurrent merge sort", ConcurrentMergeSort},
+		{"Concurrent quicksort", ConcurrentQuicksort},
+		{"Introspective sort", IntrospectiveSort}}
+	a := make([]int, n)
+	for round := 0; round < rounds; round++ {
+		for _, s := range sorts {
+			for i := range a {
+				a[i] = rand.Intn(n)
+			}
+			if round%2 == 1 {
+				// the largest value last leaves an empty sub-list after the
+				// first quicksort partition, which must still signal completion
+				a[n-1] = n
+			}
+			s.sort(a)
+			if !IsSorted(a) {
+				t.Errorf("%s failed on round %v", s.name, round)
+			}
+		}
+	}
+}
+
+func TestMergeSortIndices(t *testing.T) {
+	if p := MergeSortIndices(nil); len(p) != 0 {
+		t.Errorf("MergeSortIndices of an empty slice should be empty but is %v", p)
+	}
+	keys := []int{3, 1, 2, 1, 3, 0, 2, 1}
+	expected := []int{5, 1, 3, 7, 2, 6, 0, 4}
+	p := MergeSortIndices(keys)
+	for i := range expected {
+		if p[i] != expected[i] {
+			t.Errorf("MergeSortIndices should return %v but returned %v", expected, p)
+			break
+		}
+	}
+
+	keys = make([]int, 10000)
+	for i := range keys {
+		keys[i] = rand.Intn(100)
+	}
+	p = MergeSortIndices(keys)
+	for i := 1; i < len(p); i++ {
+		if keys[p[i]] < keys[p[i-1]] || (keys[p[i]] == keys[p[i-1]] && p[i] < p[i-1]) {
+			t.Errorf("MergeSortIndices is not a stable sort at position %v", i)
+			break
+		}
+	}
+}
+
+func TestStableSort(t *testing.T) {
+	type employee struct {
+		dept, name string
+	}
+	StableSort(nil, func(a, b interface{}) bool { return false })
+
+	// sort by name, then by department; names stay sorted within departments
+	data := []interface{}{
+		employee{"sales", "kim"}, employee{"dev", "ann"}, employee{"ops", "lee"},
+		employee{"dev", "zed"}, employee{"sales", "bob"}, employee{"dev", "max"},
+		employee{"ops", "eve"}, employee{"sales", "ann"}, employee{"dev", "bob"}}
+	StableSort(data, func(a, b interface{}) bool { return a.(employee).name < b.(employee).name })
+	StableSort(data, func(a, b interface{}) bool { return a.(employee).dept < b.(employee).dept })
+	expected := []employee{
+		{"dev", "ann"}, {"dev", "bob"}, {"dev", "max"}, {"dev", "zed"},
+		{"ops", "eve"}, {"ops", "lee"},
+		{"sales", "ann"}, {"sales", "bob"}, {"sales", "kim"}}
+	for i, e := range expected {
+		if data[i] != e {
+			t.Errorf("StableSort should produce %v but produced %v", expected, data)
+			break
+		}
+	}
+
+	// check stability on many equal keys by recording original positions
+	type item struct{ key, position int }
+	data = make([]interface{}, 5000)
+	for i := range data {
+		data[i] = item{rand.Intn(50), i}
+	}
+	StableSort(data, func(a, b interface{}) bool { return a.(item).key < b.(item).key })
+	for i := 1; i < len(data); i++ {
+		p, q := data[i-1].(item), data[i].(item)
+		if q.key < p.key || (p.key == q.key && q.position < p.position) {
+			t.Errorf("StableSort is not stable at position %v", i)
+			break
+		}
+	}
+}
+
+func TestMaxSubarraySum(t *testing.T) {
+	if _, _, _, err := MaxSubarraySum(nil); err == nil {
+		t.Error("MaxSubarraySum should fail on an empty slice")
+	}
+	data := []struct {
+		a               []int
+		sum, start, end int
+	}{{[]int{-2, 1, -3, 4, -1, 2, 1, -5, 4}, 6, 3, 7},
+		{[]int{5, -9, 6, -2, 3}, 7, 2, 5},
+		{[]int{1, 2, 3, 4}, 10, 0, 4},
+		{[]int{7}, 7, 0, 1},
+		{[]int{-3, -1, -4, -2}, -1, 1, 2},
+		{[]int{-5}, -5, 0, 1}}
+	for _, d := range data {
+		sum, start, end, err := MaxSubarraySum(d.a)
+		if err != nil || sum != d.sum || start != d.start || end != d.end {
+			t.Errorf("MaxSubarraySum of %v should be %v in [%v,%v) but is %v in [%v,%v)",
+				d.a, d.sum, d.start, d.end, sum, start, end)
+		}
+	}
+
+	// compare against brute force on random slices
+	for n := 1; n < 40; n++ {
+		a := make([]int, n)
+		for i := range a {
+			a[i] = rand.Intn(21) - 10
+		}
+		best := a[0]
+		for i := range a {
+			total := 0
+			for j := i; j < n; j++ {
+				total += a[j]
+				if best < total {
+					best = total
+				}
+			}
+		}
+		sum, start, end, _ := MaxSubarraySum(a)
+		total := 0
+		for _, x := range a[start:end] {
+			total += x
+		}
+		if sum != best || total != sum {
+			t.Errorf("MaxSubarraySum of %v should be %v but is %v in [%v,%v)", a, best, sum, start, end)
+		}
+	}
+}
+
+func TestEquilibriumIndex(t *testing.T) {
+	data := []struct {
+		a     []int
+		index int
+		ok    bool
+	}{{[]int{}, 0, false},
+		{[]int{5}, 0, true},
+		{[]int{-7, 1, 5, 2, -4, 3, 0}, 3, true},
+		{[]int{1, 2, 3, 3}, 2, true},
+		{[]int{1, 2, 3}, 0, false},
+		{[]int{1, -1, 4}, 2, true},
+		{[]int{4, 2, -2}, 0, true},
+		{[]int{0, 0, 0}, 0, true},
+		{[]int{2, 1, 2, 1, 2}, 2, true}}
+	for _, d := range data {
+		if index, ok := EquilibriumIndex(d.a); index != d.index || ok != d.ok {
+			t.Errorf("EquilibriumIndex of %v should be %v, %v but is %v, %v", d.a, d.index, d.ok, index, ok)
+		}
+	}
+}
+
+func TestSliceGCD(t *testing.T) {
+	if g, err := SliceGCD([]int{}); err == nil {
+		t.Errorf("SliceGCD should fail on an empty slice but returned %v", g)
+	}
+	expected := []struct {
+		a   []int
+		gcd int
+	}{
+		{[]int{12}, 12}, {[]int{-12}, 12}, {[]int{12, 18, 30}, 6}, {[]int{84, -36, 60}, 12},
+		{[]int{9, 28}, 1}, {[]int{6, 10, 15}, 1}, {[]int{0, 8, 12}, 4}, {[]int{8, 0}, 8},
+		{[]int{0}, 0}, {[]int{0, 0, 0}, 0},
+	}
+	for _, e := range expected {
+		if g, err := SliceGCD(e.a); err != nil {
+			t.Errorf("SliceGCD fails on %v: %v", e.a, err)
+		} else if g != e.gcd {
+			t.Errorf("SliceGCD of %v should be %v but is %v", e.a, e.gcd, g)
+		}
+	}
+}
+
+func TestPairsWithSum(t *testing.T) {
+	expected := []struct {
+		a      []int
+		target int
+		pairs  [][2]int
+	}{
+		{[]int{}, 5, [][2]int{}},
+		{[]int{5}, 10, [][2]int{}},
+		{[]int{1, 2, 3, 9}, 20, [][2]int{}},
+		{[]int{1, 4, 2, 3, 5, 0}, 5, [][2]int{{0, 1}, {2, 3}, {4, 5}}},
+		{[]int{3, 3, 3}, 6, [][2]int{{0, 1}, {0, 2}, {1, 2}}},
+		{[]int{2, -2, 7, -2, 0}, 0, [][2]int{{0, 1}, {0, 3}}},
+		{[]int{-1, 8, 6, -1, 1}, 7, [][2]int{{0, 1}, {1, 3}, {2, 4}}},
+	}
+	for _, e := range expected {
+		pairs := PairsWithSum(e.a, e.target)
+		if len(pairs) != len(e.pairs) {
+			t.Errorf("PairsWithSum(%v, %v) should be %v but is %v", e.a, e.target, e.pairs, pairs)
+			continue
+		}
+		for i := range pairs {
+			if pairs[i] != e.pairs[i] {
+				t.Errorf("PairsWithSum(%v, %v) should be %v but is %v", e.a, e.target, e.pairs, pairs)
+				break
+			}
+		}
+	}
+}